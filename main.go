@@ -2,16 +2,22 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"sync"
 	"time"
+	_ "time/tzdata"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/google/uuid"
 	"github.com/stephensulimani/internly-bot/pkg"
 	"github.com/stephensulimani/internly-bot/pkg/commands"
+	"github.com/stephensulimani/internly-bot/pkg/filter"
 	"github.com/stephensulimani/internly-bot/pkg/models"
 	"github.com/stephensulimani/internly-bot/pkg/scraper"
 	"github.com/stephensulimani/internly-bot/pkg/scraper/sites"
@@ -93,7 +99,10 @@ func main() {
 		logger.Fatal(err)
 	}
 
-	db.AutoMigrate(&models.Job{}, &models.Guild{}, &models.SentJob{}, &models.Subscription{})
+	err = models.Migrate(db)
+	if err != nil {
+		logger.Fatal(err)
+	}
 
 	discord, err := discordgo.New("Bot " + config.BotToken)
 	if err != nil {
@@ -137,22 +146,20 @@ func main() {
 
 	availableCommands := []commands.Command{
 		commands.ConfigureCommand(db),
+		commands.FeedCommand(logger, db),
+		commands.PingCommand(logger, db),
 		commands.SubscribeCommand(logger, db),
 		commands.SubscriptionsCommand(logger, db),
+		commands.SubscriptionCommand(logger, db),
 		commands.UnsubscribeCommand(logger, db),
+		commands.PauseCommand(logger, db),
+		commands.ResumeCommand(logger, db),
 		commands.HelpCommand(),
 	}
 
-	commandHandlers := make(map[string]commands.Command)
-	for _, v := range availableCommands {
-		commandHandlers[v.Command.Name] = v
-	}
+	router := commands.NewRouter(logger, availableCommands)
 
-	discord.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if h, ok := commandHandlers[i.ApplicationCommandData().Name]; ok {
-			h.Execute(s, i)
-		}
-	})
+	discord.AddHandler(router.Handle)
 
 	err = discord.Open()
 	if err != nil {
@@ -174,6 +181,8 @@ func main() {
 
 	go Subscriptions(config, discord, db, logger)
 
+	go Closer(config, discord, db, logger)
+
 	sigch := make(chan os.Signal, 1)
 	signal.Notify(sigch, os.Interrupt)
 	<-sigch
@@ -189,6 +198,29 @@ func Scraper(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log *zap.
 	scrapers := []scraper.Scraper{
 		sites.NewSimplifyJobs(log, db, nil),
 	}
+	if len(cfg.Greenhouse) > 0 {
+		scrapers = append(scrapers, sites.NewGreenhouse(log, db, nil, cfg.Greenhouse))
+	}
+	if len(cfg.Lever) > 0 {
+		scrapers = append(scrapers, sites.NewLever(log, db, nil, cfg.Lever))
+	}
+	if len(cfg.Ashby) > 0 {
+		scrapers = append(scrapers, sites.NewAshby(log, db, nil, cfg.Ashby))
+	}
+	if len(cfg.Workday) > 0 {
+		scrapers = append(scrapers, sites.NewWorkday(log, db, nil, cfg.Workday))
+	}
+	for _, site := range cfg.Sites {
+		switch site.Format {
+		case models.SiteFormatTable:
+			scrapers = append(scrapers, sites.NewMarkdownTable(log, db, nil, site))
+		default:
+			scrapers = append(scrapers, scraper.NewSiteScraper(site, db, nil, log))
+		}
+	}
+	for _, feed := range cfg.RSS {
+		scrapers = append(scrapers, sites.NewRSS(log, db, nil, feed))
+	}
 	for true {
 		jobs := make(chan *scraper.Scraper, workers)
 		var wg sync.WaitGroup
@@ -220,119 +252,303 @@ func Sender(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log *zap.S
 	const delay = 10 * time.Second
 	for true {
 		time.Sleep(delay)
-		var guilds []models.Guild
-		err := db.Where("deleted_at is NULL").Find(&guilds).Error
+
+		now := time.Now()
+		guilds, err := openGuilds(db, now)
 		if err != nil {
 			log.Error(err)
 			continue
 		}
 
-		log.Infof("Found %d guilds", len(guilds))
+		if len(guilds) == 0 {
+			continue
+		}
+
+		guildIDs := make([]uuid.UUID, len(guilds))
+		for i, guild := range guilds {
+			guildIDs[i] = guild.ID
+		}
 
-		guildCh := make(chan *models.Guild, workers)
+		var feeds []models.Feed
+		err = db.Table("feeds").
+			Select("feeds.*").
+			Joins("JOIN guilds ON guilds.id = feeds.guild_id").
+			Where("guilds.deleted_at IS NULL AND guilds.id IN ?", guildIDs).
+			Find(&feeds).Error
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		log.Infof("Found %d feeds", len(feeds))
+
+		// The feeds posting to a channel are sent one after another, so that each counts the role pings of the others
+		// toward the channel's hourly limit.
+		channelIDs := []string{}
+		channelFeeds := make(map[string][]*models.Feed)
+		for i := range feeds {
+			channelID := feeds[i].ChannelID
+			if _, ok := channelFeeds[channelID]; !ok {
+				channelIDs = append(channelIDs, channelID)
+			}
+			channelFeeds[channelID] = append(channelFeeds[channelID], &feeds[i])
+		}
+
+		feedCh := make(chan []*models.Feed, workers)
 		var wg sync.WaitGroup
 
+		// Guilds with a feed that still has jobs waiting keep their batch open until every job has been posted.
+		var mu sync.Mutex
+		unfinished := make(map[uuid.UUID]bool)
+
 		for range workers {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				for ch := range guildCh {
-					jobTypes := []string{"NEW_GRAD", "INTERN"}
-
-					for _, jobType := range jobTypes {
-						if jobType == "NEW_GRAD" && ch.NewGradChannelID == "" {
-							continue
+				for ch := range feedCh {
+					for _, feed := range ch {
+						if !sendFeed(discord, db, log, feed) {
+							mu.Lock()
+							unfinished[feed.GuildID] = true
+							mu.Unlock()
 						}
+					}
+				}
+			}()
+		}
+		for _, channelID := range channelIDs {
+			feedCh <- channelFeeds[channelID]
+		}
+		close(feedCh)
+		wg.Wait()
 
-						if jobType == "INTERN" && ch.InternChannelID == "" {
-							continue
-						}
+		for _, guild := range guilds {
+			if !guild.Batched() || unfinished[guild.ID] {
+				continue
+			}
+			err = db.Model(&models.Guild{}).Where("id = ?", guild.ID).Update("last_batch_at", now).Error
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
 
-						var channelId string
+// openGuilds returns the guilds whose feeds may post now, leaving out those in their quiet hours or waiting for their
+// next batch time. A batch stays open until it has been recorded as posted, which is left to the caller.
+func openGuilds(db *gorm.DB, now time.Time) ([]models.Guild, error) {
+	var guilds []models.Guild
+	err := db.Where("deleted_at IS NULL").Find(&guilds).Error
+	if err != nil {
+		return nil, err
+	}
 
-						switch jobType {
-						case "NEW_GRAD":
-							channelId = ch.NewGradChannelID
-						case "INTERN":
-							channelId = ch.InternChannelID
-						}
+	open := []models.Guild{}
 
-						var jobs []models.Job
-						err := db.Table("jobs").
-							Select("jobs.*").
-							Joins("LEFT JOIN sent_jobs ON jobs.id = sent_jobs.job_id AND sent_jobs.guild_id = ?", ch.ID).
-							Where("sent_jobs.job_id IS NULL AND jobs.job_type = ? AND jobs.first_seen > ?", jobType, time.Now().Add(-30*24*time.Hour)).
-							Limit(250).
-							Order("jobs.first_seen ASC").
-							Find(&jobs).Error
-						if err != nil {
-							log.Error(err)
-							continue
-						}
+	for _, guild := range guilds {
+		if guild.PostingOpen(now) {
+			open = append(open, guild)
+		}
+	}
 
-						log.Infof("Found %d %s jobs for guild: %s", len(jobs), jobType, ch.GuildID)
-
-						for _, job := range jobs {
-
-							msg, err := discord.ChannelMessageSendComplex(channelId, GenerateMessage(&job))
-							if err != nil {
-								errCode := err.(*discordgo.RESTError).Message.Code
-								if errCode == discordgo.ErrCodeInvalidFormBody {
-									// This error occurs when there is some issue with the Embed, meaning something regarding the
-									// job is malformed.
-									sentJob := models.SentJob{
-										GuildID: ch.ID,
-										JobID:   job.ID,
-										Error:   true,
-									}
-									err = db.Save(&sentJob).Error
-									if err != nil {
-										log.Error(err)
-									}
-									log.Errorf("Error sending job: %s to channelID: %s", job.ID, channelId)
-									continue
-								}
-								if errCode == discordgo.ErrCodeUnknownChannel {
-									log.Errorf("Error sending job: %s to channelID: %s. The channel no longer exists.", job.ID, channelId)
-									switch jobType {
-									case string(models.NEW_GRAD):
-										ch.NewGradChannelID = ""
-										db.Save(&ch)
-									case string(models.INTERN):
-										ch.InternChannelID = ""
-										db.Save(&ch)
-									}
-									continue
-								}
-								log.Error(err)
-								continue
-							}
-
-							sentJob := models.SentJob{
-								MessageId: msg.ID,
-								GuildID:   ch.ID,
-								JobID:     job.ID,
-							}
-
-							err = db.Save(&sentJob).Error
-							if err != nil {
-								log.Error(err)
-								continue
-							}
-							time.Sleep(500 * time.Millisecond)
-						}
+	return open, nil
+}
 
-					}
+// sendFeed posts the jobs matching a feed that haven't been posted to it yet, oldest first. It reports whether every
+// waiting job was posted, which isn't the case when sending fails or there are more than it posts at once.
+func sendFeed(discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger, feed *models.Feed) bool {
+	const limit = 250
+
+	var jobs []models.Job
+	query := db.Table("jobs").
+		Select("jobs.*").
+		Joins("LEFT JOIN sent_jobs ON jobs.id = sent_jobs.job_id AND sent_jobs.feed_id = ?", feed.ID).
+		Where("sent_jobs.job_id IS NULL AND jobs.status != ? AND jobs.first_seen > ? AND jobs.created_at > ?", models.JobStatusClosed, time.Now().Add(-30*24*time.Hour), feed.CreatedAt)
+	err := filter.Query(query, &feed.Filter).
+		Limit(limit).
+		Order("jobs.first_seen ASC").
+		Find(&jobs).Error
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	log.Infof("Found %d jobs for feed: %s in guild: %s", len(jobs), feed.Name, feed.GuildID)
+
+	if len(jobs) == 0 {
+		return true
+	}
+
+	// Forum channels get a thread per job, so the channel's type and tags are needed before anything is sent.
+	channel, err := feedChannel(discord, feed.ChannelID)
+	if err != nil {
+		var restErr *discordgo.RESTError
+		if errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownChannel {
+			log.Errorf("Channel %s no longer exists, so feed %s is removed.", feed.ChannelID, feed.Name)
+			err = db.Delete(feed).Error
+		}
+		if err != nil {
+			log.Error(err)
+			return false
+		}
+		return true
+	}
+
+	var pings []models.RolePing
+	err = db.Where("guild_id = ? AND (channel_id = '' OR channel_id = ?)", feed.GuildID, feed.ChannelID).Order("created_at ASC").Find(&pings).Error
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	pinged, err := countRolePings(db, feed.ChannelID, time.Now().Add(-time.Hour))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	complete := len(jobs) < limit
 
+	for _, job := range jobs {
+		msg := GenerateMessage(&job)
+
+		// The feed's own role is mentioned with every job and role pings with the jobs they match, together up to the
+		// channel's hourly limit.
+		mentioned := []string{}
+		if feed.RoleID != "" && pinged < models.MaxRolePingsPerHour {
+			mentioned = append(mentioned, feed.RoleID)
+		}
+		mentioned = append(mentioned, matchRolePings(pings, &job, feed.RoleID, models.MaxRolePingsPerHour-pinged-len(mentioned))...)
+		pinged += len(mentioned)
+		if len(mentioned) > 0 {
+			mentions := make([]string, len(mentioned))
+			for n, roleID := range mentioned {
+				mentions[n] = fmt.Sprintf("<@&%s>", roleID)
+			}
+			msg.Content = strings.Join(mentions, " ")
+			msg.AllowedMentions = &discordgo.MessageAllowedMentions{Roles: mentioned}
+		}
+
+		var sent *discordgo.Message
+		if channel.Type == discordgo.ChannelTypeGuildForum {
+			var thread *discordgo.Channel
+			thread, err = discord.ForumThreadStartComplex(channel.ID, GenerateForumThread(&job, channel), msg)
+			if err == nil {
+				// The thread's starter message shares its ID, so it can be edited like any other message once the
+				// job closes.
+				sent = &discordgo.Message{ID: thread.ID, ChannelID: thread.ID}
+			}
+		} else {
+			sent, err = discord.ChannelMessageSendComplex(channel.ID, msg)
+		}
+		if err != nil {
+			var restErr *discordgo.RESTError
+			if !errors.As(err, &restErr) || restErr.Message == nil {
+				log.Error(err)
+				return false
+			}
+			switch restErr.Message.Code {
+			case discordgo.ErrCodeInvalidFormBody:
+				// This error occurs when there is some issue with the Embed, meaning something regarding the
+				// job is malformed.
+				sentJob := models.SentJob{
+					GuildID: feed.GuildID,
+					FeedID:  &feed.ID,
+					JobID:   job.ID,
+					Error:   true,
 				}
-			}()
+				err = db.Save(&sentJob).Error
+				if err != nil {
+					log.Error(err)
+					complete = false
+				}
+				log.Errorf("Error sending job: %s to channelID: %s", job.ID, feed.ChannelID)
+				continue
+			case discordgo.ErrCodeUnknownChannel:
+				log.Errorf("Error sending job: %s to channelID: %s. The channel no longer exists, so feed %s is removed.", job.ID, feed.ChannelID, feed.Name)
+				err = db.Delete(feed).Error
+				if err != nil {
+					log.Error(err)
+					return false
+				}
+				return true
+			}
+			log.Error(err)
+			complete = false
+			continue
 		}
-		for _, g := range guilds {
-			guildCh <- &g
+
+		sentJob := models.SentJob{
+			MessageId:   sent.ID,
+			ChannelID:   sent.ChannelID,
+			GuildID:     feed.GuildID,
+			FeedID:      &feed.ID,
+			JobID:       job.ID,
+			PingedRoles: mentioned,
 		}
-		close(guildCh)
-		wg.Wait()
+
+		err = db.Save(&sentJob).Error
+		if err != nil {
+			log.Error(err)
+			complete = false
+			continue
+		}
+		time.Sleep(500 * time.Millisecond)
 	}
+
+	return complete
+}
+
+// matchRolePings returns the roles to mention with a job, which are those whose pings match it other than the feed's
+// own role, at most limit of them.
+func matchRolePings(pings []models.RolePing, job *models.Job, feedRoleID string, limit int) []string {
+	roleIDs := []string{}
+	for i := range pings {
+		if len(roleIDs) >= limit {
+			break
+		}
+		if pings[i].RoleID == feedRoleID || !filter.Match(&pings[i].Filter, job) {
+			continue
+		}
+		roleIDs = append(roleIDs, pings[i].RoleID)
+	}
+	return roleIDs
+}
+
+// countRolePings counts the roles mentioned by the feeds posting in a channel since a time, both their own and through
+// role pings. Jobs posted to a forum are counted by the forum, rather than by their own threads.
+func countRolePings(db *gorm.DB, channelID string, since time.Time) (int, error) {
+	var pingedRoles []models.StringSlice
+	err := db.Table("sent_jobs").
+		Joins("JOIN feeds ON feeds.id = sent_jobs.feed_id").
+		Where("feeds.channel_id = ? AND sent_jobs.created_at > ? AND sent_jobs.pinged_roles IS NOT NULL", channelID, since).
+		Pluck("sent_jobs.pinged_roles", &pingedRoles).Error
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, roles := range pingedRoles {
+		count += len(filter.Terms(roles))
+	}
+	return count, nil
+}
+
+// feedChannel looks up the channel a feed posts to, preferring the state the gateway keeps up to date.
+func feedChannel(discord *discordgo.Session, channelID string) (*discordgo.Channel, error) {
+	channel, err := discord.State.Channel(channelID)
+	if err == nil {
+		return channel, nil
+	}
+	return discord.Channel(channelID)
+}
+
+// subscriptionMatch is a job matched by one or more of a user's subscriptions.
+type subscriptionMatch struct {
+	job           models.Job
+	subscriptions []*models.Subscription
+	// handles are the handles of the matching subscriptions, as shown in /subscriptions.
+	handles []string
 }
 
 func Subscriptions(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger) {
@@ -340,8 +556,14 @@ func Subscriptions(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log
 	const delay = 10 * time.Second
 	for true {
 		time.Sleep(delay)
+
+		err := models.ResumeExpiredPauses(db, time.Now())
+		if err != nil {
+			log.Error(err)
+		}
+
 		var subscriptions []models.Subscription
-		err := db.Where("deleted_at is NULL").Find(&subscriptions).Error
+		err = db.Where("deleted_at is NULL").Order("created_at DESC").Find(&subscriptions).Error
 		if err != nil {
 			log.Error(err)
 			continue
@@ -349,150 +571,482 @@ func Subscriptions(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log
 
 		log.Infof("Found %d subscriptions", len(subscriptions))
 
-		subCh := make(chan *models.Subscription, workers)
+		// Deliveries are deduplicated per user, so all of a user's subscriptions are handled together.
+		userIDs := []string{}
+		userSubscriptions := make(map[string][]*models.Subscription)
+		for i := range subscriptions {
+			userID := subscriptions[i].UserID
+			if _, ok := userSubscriptions[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			userSubscriptions[userID] = append(userSubscriptions[userID], &subscriptions[i])
+		}
+
+		userCh := make(chan []*models.Subscription, workers)
 		var wg sync.WaitGroup
 
 		for range workers {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				for ch := range subCh {
+				for ch := range userCh {
+					deliverSubscriptions(discord, db, log, ch)
+				}
+			}()
+		}
+		for _, userID := range userIDs {
+			userCh <- userSubscriptions[userID]
+		}
+		close(userCh)
+		wg.Wait()
+	}
+}
 
-					locationsQuery := ""
+// deliverSubscriptions sends a user's matched jobs. Jobs matched by an instant subscription are sent individually,
+// while the rest wait for the next digest of a subscription that matches them. Paused subscriptions are left out.
+func deliverSubscriptions(discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger, subscriptions []*models.Subscription) {
+	userID := subscriptions[0].UserID
+	now := time.Now()
 
-					for i, location := range ch.Locations {
-						if location == "" {
-							break
-						}
-						if i == 0 {
-							locationsQuery += "("
-						}
-						locationsQuery += fmt.Sprintf("location LIKE '%%%s%%'", location)
-						if i == len(ch.Locations)-1 {
-							locationsQuery += ")"
-						} else {
-							locationsQuery += " OR "
-						}
-					}
+	instant := []*models.Subscription{}
+	digest := []*models.Subscription{}
+	for _, subscription := range subscriptions {
+		if !subscription.Active {
+			continue
+		}
+		if !subscription.IsDigest() {
+			instant = append(instant, subscription)
+		} else if subscription.DigestDue(now) {
+			digest = append(digest, subscription)
+		}
+	}
 
-					companiesQuery := ""
+	if len(instant) > 0 {
+		matches, err := findSubscriptionMatches(db, userID, instant)
+		if err != nil {
+			log.Error(err)
+			return
+		}
 
-					for i, company := range ch.Companies {
-						if company == "" {
-							break
-						}
-						if i == 0 {
-							companiesQuery += "("
-						}
-						companiesQuery += fmt.Sprintf("company LIKE '%%%s%%'", company)
-						if i == len(ch.Companies)-1 {
-							companiesQuery += ")"
-						} else {
-							companiesQuery += " OR "
-						}
-					}
+		log.Infof("Found %d jobs for User: %s", len(matches), userID)
 
-					rolesQuery := ""
+		if len(matches) > 0 {
+			sendSubscriptionMatches(discord, db, log, userID, matches)
+		}
+	}
 
-					for i, role := range ch.Roles {
-						if role == "" {
-							break
-						}
-						if i == 0 {
-							rolesQuery += "("
-						}
-						rolesQuery += fmt.Sprintf("role LIKE '%%%s%%'", role)
-						if i == len(ch.Roles)-1 {
-							rolesQuery += ")"
-						} else {
-							rolesQuery += " OR "
-						}
-					}
+	if len(digest) > 0 {
+		// Instant deliveries are made first, so that a job matched by both kinds of subscription isn't sent twice.
+		matches, err := findSubscriptionMatches(db, userID, digest)
+		if err != nil {
+			log.Error(err)
+			return
+		}
 
-					queries := []string{}
+		log.Infof("Found %d digest jobs for User: %s", len(matches), userID)
 
-					if locationsQuery != "" {
-						queries = append(queries, locationsQuery)
-					}
+		if len(matches) > 0 {
+			err = sendDigest(discord, db, userID, digestTitle(digest), matches)
+			if err != nil {
+				log.Errorf("Error sending digest to user: %s: %v", userID, err)
+				// Digests that would fail again if retried, such as to a user not accepting DMs, wait for their next
+				// time instead.
+				if _, permanent := permanentSendError(err); !permanent {
+					return
+				}
+			}
+		}
 
-					if companiesQuery != "" {
-						queries = append(queries, companiesQuery)
-					}
+		for _, subscription := range digest {
+			err = db.Model(subscription).Update("last_digest_at", now).Error
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
 
-					if rolesQuery != "" {
-						queries = append(queries, rolesQuery)
-					}
+// sendSubscriptionMatches sends each match to the user in its own message.
+func sendSubscriptionMatches(discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger, userID string, matches []*subscriptionMatch) {
+	user_chan, err := discord.UserChannelCreate(userID)
+	if err != nil {
+		log.Errorf("Error creating user channel with ID %s: %v", userID, err)
+		return
+	}
 
-					query := strings.Join(queries, " AND ")
-
-					var jobs []models.Job
-					err := db.Table("jobs").
-						Select("jobs.*").
-						Joins("LEFT JOIN sent_jobs ON jobs.id = sent_jobs.job_id AND sent_jobs.guild_id = ?", ch.ID).
-						Where("sent_jobs.job_id IS NULL AND jobs.job_type = ? AND jobs.first_seen > ? AND jobs.created_at > ?", ch.JobType, time.Now().Add(-30*24*time.Hour), ch.CreatedAt).
-						Where(query).
-						Limit(250).
-						Order("jobs.first_seen ASC").
-						Find(&jobs).Error
-					if err != nil {
-						log.Error(err)
-						continue
-					}
+	for _, match := range matches {
+		delivery := newSubscriptionDelivery(userID, user_chan.ID, match)
 
-					log.Infof("Found %d %s jobs for User: %s", len(jobs), ch.JobType, ch.UserID)
-					user_chan, err := discord.UserChannelCreate(ch.UserID)
-					if err != nil {
-						log.Errorf("Error creating user channel with ID %s: %v", ch.UserID, err)
-						continue
-					}
+		msg := GenerateMessage(&match.job)
+		msg.Embeds[0].Fields = append(msg.Embeds[0].Fields, &discordgo.MessageEmbedField{
+			Name:  "Matched",
+			Value: strings.Join(match.handles, ", "),
+		})
 
-					for _, job := range jobs {
+		sent, err := discord.ChannelMessageSendComplex(user_chan.ID, msg)
+		if err != nil {
+			if reason, permanent := permanentSendError(err); permanent {
+				// These will fail again if retried, so the attempt is recorded instead.
+				delivery.Status = models.DeliveryStatusFailed
+				delivery.Error = reason
+				saveErr := db.Save(&delivery).Error
+				if saveErr != nil {
+					log.Error(saveErr)
+				}
+			}
+			log.Errorf("Error sending job: %s to user: %s: %v", match.job.ID, userID, err)
+			break
+		}
 
-						msg, err := discord.ChannelMessageSendComplex(user_chan.ID, GenerateMessage(&job))
-						if err != nil {
-							log.Error(err)
-							break
-						}
+		delivery.MessageID = sent.ID
 
-						sentJob := models.SentJob{
-							MessageId: msg.ID,
-							GuildID:   ch.ID,
-							JobID:     job.ID,
-						}
+		err = db.Save(&delivery).Error
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
 
-						err = db.Save(&sentJob).Error
-						if err != nil {
-							log.Error(err)
-							continue
-						}
-						time.Sleep(500 * time.Millisecond)
-					}
+// sendDigest sends the matches bundled into as few messages as possible, recording every job in a message as
+// delivered once it is sent.
+func sendDigest(discord *discordgo.Session, db *gorm.DB, userID string, title string, matches []*subscriptionMatch) error {
+	user_chan, err := discord.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range digestMessages(title, matches) {
+		deliveries := make([]models.SubscriptionDelivery, len(page.matches))
+		for i, match := range page.matches {
+			deliveries[i] = newSubscriptionDelivery(userID, user_chan.ID, match)
+			deliveries[i].Digest = true
+		}
 
+		sent, err := discord.ChannelMessageSendComplex(user_chan.ID, page.message)
+		if err != nil {
+			if reason, permanent := permanentSendError(err); permanent {
+				for i := range deliveries {
+					deliveries[i].Status = models.DeliveryStatusFailed
+					deliveries[i].Error = reason
 				}
-			}()
+				saveErr := db.Save(&deliveries).Error
+				if saveErr != nil {
+					return saveErr
+				}
+			}
+			return err
 		}
-		for _, s := range subscriptions {
-			subCh <- &s
+
+		for i := range deliveries {
+			deliveries[i].MessageID = sent.ID
+		}
+
+		err = db.Save(&deliveries).Error
+		if err != nil {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return nil
+}
+
+func newSubscriptionDelivery(userID string, channelID string, match *subscriptionMatch) models.SubscriptionDelivery {
+	delivery := models.SubscriptionDelivery{
+		SubscriptionID: match.subscriptions[0].ID,
+		UserID:         userID,
+		JobID:          match.job.ID,
+		ChannelID:      channelID,
+		Status:         models.DeliveryStatusSent,
+	}
+	for _, subscription := range match.subscriptions {
+		delivery.MatchedSubscriptions = append(delivery.MatchedSubscriptions, subscription.ID.String())
+	}
+	return delivery
+}
+
+// permanentSendError reports whether sending a DM failed in a way that will fail again if retried, such as the user
+// not accepting DMs, along with Discord's reason.
+func permanentSendError(err error) (string, bool) {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil {
+		return "", false
+	}
+	switch restErr.Message.Code {
+	case discordgo.ErrCodeInvalidFormBody, discordgo.ErrCodeCannotSendMessagesToThisUser:
+		return restErr.Message.Message, true
+	}
+	return "", false
+}
+
+// findSubscriptionMatches returns the jobs matching any of the given subscriptions that haven't been delivered to
+// the user yet, oldest first.
+func findSubscriptionMatches(db *gorm.DB, userID string, subscriptions []*models.Subscription) ([]*subscriptionMatch, error) {
+	matches := []*subscriptionMatch{}
+	byJob := make(map[uuid.UUID]*subscriptionMatch)
+
+	for _, subscription := range subscriptions {
+		var jobs []models.Job
+		query := db.Table("jobs").
+			Select("jobs.*").
+			Joins("LEFT JOIN subscription_deliveries ON jobs.id = subscription_deliveries.job_id AND subscription_deliveries.user_id = ?", userID).
+			Where("subscription_deliveries.job_id IS NULL AND jobs.status != ? AND jobs.first_seen > ? AND jobs.created_at > ?", models.JobStatusClosed, time.Now().Add(-30*24*time.Hour), subscription.DeliverSince())
+		err := filter.Query(query, &subscription.Filter).
+			Limit(250).
+			Order("jobs.first_seen ASC").
+			Find(&jobs).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, job := range jobs {
+			match, ok := byJob[job.ID]
+			if !ok {
+				match = &subscriptionMatch{job: job}
+				byJob[job.ID] = match
+				matches = append(matches, match)
+			}
+			match.subscriptions = append(match.subscriptions, subscription)
+			match.handles = append(match.handles, subscription.Handle)
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b *subscriptionMatch) int {
+		return a.job.FirstSeen.Compare(b.job.FirstSeen)
+	})
+
+	return matches, nil
+}
+
+// jobMessage is a message, sent to a guild channel or a subscriber's DMs, whose job has closed or reopened since it
+// was last edited.
+type jobMessage struct {
+	table     string
+	id        uuid.UUID
+	messageID string
+	channelID string
+	jobID     uuid.UUID
+	// digest is set when the message is a digest, which lists other jobs as well.
+	digest bool
+}
+
+// Closer edits every message that was sent for a job which has since closed, so that members stop applying to it, and
+// restores them if the job reopens. Each message is marked once edited, so a sweep interrupted by a restart resumes
+// where it left off.
+func Closer(cfg *pkg.Config, discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger) {
+	const delay = 30 * time.Second
+	for true {
+		time.Sleep(delay)
+
+		messages, err := findJobMessages(discord, db, log)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		if len(messages) == 0 {
+			continue
+		}
+
+		log.Infof("Found %d messages for closed or reopened jobs", len(messages))
+
+		for _, message := range messages {
+			var job models.Job
+			err := db.Where("id = ?", message.jobID).First(&job).Error
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+
+			// discordgo waits out rate limits itself, so only errors that can never succeed are given up on.
+			err = editJobMessage(discord, message, &job)
+			if err != nil {
+				var restErr *discordgo.RESTError
+				if !errors.As(err, &restErr) || restErr.Message == nil {
+					log.Error(err)
+					continue
+				}
+				switch restErr.Message.Code {
+				case discordgo.ErrCodeUnknownMessage, discordgo.ErrCodeUnknownChannel, discordgo.ErrCodeMissingAccess:
+					log.Infof("Message %s for job %s can no longer be edited", message.messageID, job.ID)
+				default:
+					log.Error(err)
+					continue
+				}
+			}
+
+			err = db.Table(message.table).Where("id = ?", message.id).Update("marked_closed", job.IsClosed()).Error
+			if err != nil {
+				log.Error(err)
+			}
+			time.Sleep(500 * time.Millisecond)
 		}
-		close(subCh)
-		wg.Wait()
 	}
 }
 
+// editJobMessage updates a message to show whether its job is closed. The message is read back first, so that what
+// was added when it was sent, such as the subscriptions a DM matched or the other jobs in a digest, is kept.
+func editJobMessage(discord *discordgo.Session, message jobMessage, job *models.Job) error {
+	original, err := discord.ChannelMessage(message.channelID, message.messageID)
+	if err != nil {
+		return err
+	}
+
+	edit := discordgo.NewMessageEdit(message.channelID, message.messageID)
+	if message.digest {
+		embeds := markDigestJob(original.Embeds, job)
+		edit.Embeds = &embeds
+	} else {
+		msg := markJobMessage(original.Embeds, job)
+		edit.Embeds = &msg.Embeds
+		edit.Components = &msg.Components
+	}
+
+	_, err = discord.ChannelMessageEditComplex(edit)
+	return err
+}
+
+// markJobMessage regenerates a job's message to show whether the job is closed, keeping the fields that were added to
+// the original embeds when it was sent.
+func markJobMessage(original []*discordgo.MessageEmbed, job *models.Job) *discordgo.MessageSend {
+	msg := GenerateMessage(job)
+
+	generated := map[string]bool{"Closed": true}
+	for _, field := range msg.Embeds[0].Fields {
+		generated[field.Name] = true
+	}
+
+	if len(original) > 0 {
+		for _, field := range original[0].Fields {
+			if !generated[field.Name] {
+				msg.Embeds[0].Fields = append(msg.Embeds[0].Fields, field)
+			}
+		}
+	}
+
+	return msg
+}
+
+// markDigestJob strikes through a job's entry in a digest once it closes, or restores it once the job reopens,
+// leaving the digest's other entries as they are.
+func markDigestJob(embeds []*discordgo.MessageEmbed, job *models.Job) []*discordgo.MessageEmbed {
+	suffix := "](" + job.ApplicationLink + ")**"
+
+	for _, embed := range embeds {
+		lines := strings.Split(embed.Description, "\n")
+		for i, line := range lines {
+			heading := strings.TrimSuffix(strings.TrimPrefix(line, "~~"), "~~")
+			if !strings.HasPrefix(heading, "**[") || !strings.HasSuffix(heading, suffix) {
+				continue
+			}
+			lines[i] = heading
+			if job.IsClosed() {
+				lines[i] = "~~" + heading + "~~"
+			}
+		}
+		embed.Description = strings.Join(lines, "\n")
+	}
+
+	return embeds
+}
+
+// findJobMessages returns the feed and subscription messages whose job has closed since they were sent, or reopened
+// since they were marked closed. Subscription messages sent before their channel was recorded are found through the
+// subscriber's DMs, while feed messages had theirs filled in when guilds' channels became feeds.
+func findJobMessages(discord *discordgo.Session, db *gorm.DB, log *zap.SugaredLogger) ([]jobMessage, error) {
+	messages := []jobMessage{}
+
+	var sentJobs []models.SentJob
+	err := db.Table("sent_jobs").
+		Select("sent_jobs.*").
+		Joins("JOIN jobs ON jobs.id = sent_jobs.job_id").
+		Where("((jobs.status = ? AND sent_jobs.marked_closed = ?) OR (jobs.status != ? AND sent_jobs.marked_closed = ?))", models.JobStatusClosed, false, models.JobStatusClosed, true).
+		Where("sent_jobs.message_id != '' AND sent_jobs.channel_id != ''").
+		Limit(250).
+		Find(&sentJobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentJob := range sentJobs {
+		messages = append(messages, jobMessage{
+			table:     "sent_jobs",
+			id:        sentJob.ID,
+			messageID: sentJob.MessageId,
+			channelID: sentJob.ChannelID,
+			jobID:     sentJob.JobID,
+		})
+	}
+
+	var deliveries []models.SubscriptionDelivery
+	err = db.Table("subscription_deliveries").
+		Select("subscription_deliveries.*").
+		Joins("JOIN jobs ON jobs.id = subscription_deliveries.job_id").
+		Where("((jobs.status = ? AND subscription_deliveries.marked_closed = ?) OR (jobs.status != ? AND subscription_deliveries.marked_closed = ?))", models.JobStatusClosed, false, models.JobStatusClosed, true).
+		Where("subscription_deliveries.message_id != ''").
+		Limit(250).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, delivery := range deliveries {
+		channelID := delivery.ChannelID
+		if channelID == "" {
+			userChan, err := discord.UserChannelCreate(delivery.UserID)
+			if err != nil {
+				log.Errorf("Error creating user channel with ID %s: %v", delivery.UserID, err)
+				continue
+			}
+			channelID = userChan.ID
+		}
+
+		messages = append(messages, jobMessage{
+			table:     "subscription_deliveries",
+			id:        delivery.ID,
+			messageID: delivery.MessageID,
+			channelID: channelID,
+			jobID:     delivery.JobID,
+			digest:    delivery.Digest,
+		})
+	}
+
+	return messages, nil
+}
+
 func GenerateMessage(job *models.Job) *discordgo.MessageSend {
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Role", Value: job.Role},
+		{Name: "Location", Value: job.Location},
+	}
+
+	if job.Sponsorship != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Sponsorship", Value: job.Sponsorship})
+	}
+
+	color := 0x152949
+
+	if job.IsClosed() {
+		color = 0x99aab5
+		closed := "This posting is no longer accepting applications."
+		if job.ClosedAt != nil {
+			closed = fmt.Sprintf("This posting closed <t:%d:R>.", job.ClosedAt.Unix())
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Closed", Value: closed})
+	}
+
 	return &discordgo.MessageSend{
 		Embeds: []*discordgo.MessageEmbed{
 			{
 				Title: job.Company,
 				URL:   job.ApplicationLink,
-				Color: 0x152949,
+				Color: color,
 				Thumbnail: &discordgo.MessageEmbedThumbnail{
 					URL: job.Logo,
 				},
-				Fields: []*discordgo.MessageEmbedField{
-					{Name: "Role", Value: job.Role},
-					{Name: "Location", Value: job.Location},
-				},
+				Fields:      fields,
 				Description: fmt.Sprintf("First Seen: <t:%d:R>", job.FirstSeen.Unix()),
 				Footer: &discordgo.MessageEmbedFooter{
 					Text: fmt.Sprintf("Source: %s", job.Source),
@@ -506,10 +1060,122 @@ func GenerateMessage(job *models.Job) *discordgo.MessageSend {
 						Label:    "Apply",
 						Style:    discordgo.LinkButton,
 						URL:      job.ApplicationLink,
-						Disabled: false,
+						Disabled: job.IsClosed(),
 					},
 				},
 			},
 		},
 	}
 }
+
+// GenerateForumThread describes the thread a job is posted as in a forum channel. It is titled after the job, and has
+// whichever of the job's tags the forum has.
+func GenerateForumThread(job *models.Job, forum *discordgo.Channel) *discordgo.ThreadStart {
+	tagIDs := []string{}
+	for _, name := range job.ForumTags() {
+		for _, tag := range forum.AvailableTags {
+			if strings.EqualFold(tag.Name, name) {
+				tagIDs = append(tagIDs, tag.ID)
+				break
+			}
+		}
+	}
+
+	return &discordgo.ThreadStart{
+		Name:        commands.Truncate(fmt.Sprintf("%s — %s", job.Company, job.Role), 100),
+		AppliedTags: tagIDs,
+	}
+}
+
+const (
+	digestEmbedsPerMessage = 10
+	digestCharsPerMessage  = 6000
+	digestCharsPerEmbed    = 4096
+	// digestTitleChars is set aside in each embed for its title, which is only known once every page is built.
+	digestTitleChars = 64
+	digestLineChars  = 512
+	// digestClosedChars is set aside for each line to have its heading struck through if its job closes.
+	digestClosedChars = 4
+)
+
+// digestMessage is a message of a digest, along with the matches it lists.
+type digestMessage struct {
+	message *discordgo.MessageSend
+	matches []*subscriptionMatch
+}
+
+// digestTitle names a digest after how often the subscriptions it is for are delivered.
+func digestTitle(subscriptions []*models.Subscription) string {
+	for _, subscription := range subscriptions {
+		if subscription.Delivery != subscriptions[0].Delivery {
+			return "Internly Digest"
+		}
+	}
+	if subscriptions[0].Delivery == models.DeliveryModeWeekly {
+		return "Weekly Digest"
+	}
+	return "Daily Digest"
+}
+
+// digestMessages bundles matches into pages of one entry per job, filling each message up to Discord's limits of
+// 10 embeds and 6000 characters before starting the next.
+func digestMessages(title string, matches []*subscriptionMatch) []digestMessage {
+	messages := []digestMessage{}
+	embeds := []*discordgo.MessageEmbed{}
+	messageChars, embedChars := 0, 0
+
+	for _, match := range matches {
+		line := digestLine(match)
+		length := utf8.RuneCountInString(line) + digestClosedChars + 1
+
+		last := len(messages) - 1
+		switch {
+		case last >= 0 && embedChars+length <= digestCharsPerEmbed && messageChars+length <= digestCharsPerMessage:
+			embed := embeds[len(embeds)-1]
+			embed.Description += "\n" + line
+		case last >= 0 && len(messages[last].message.Embeds) < digestEmbedsPerMessage && messageChars+digestTitleChars+length <= digestCharsPerMessage:
+			embeds = append(embeds, &discordgo.MessageEmbed{Color: 0x152949, Description: line})
+			messages[last].message.Embeds = append(messages[last].message.Embeds, embeds[len(embeds)-1])
+			messageChars += digestTitleChars
+			embedChars = 0
+		default:
+			embeds = append(embeds, &discordgo.MessageEmbed{Color: 0x152949, Description: line})
+			messages = append(messages, digestMessage{message: &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embeds[len(embeds)-1]}}})
+			last++
+			messageChars, embedChars = digestTitleChars, 0
+		}
+
+		messages[last].matches = append(messages[last].matches, match)
+		messageChars += length
+		embedChars += length
+	}
+
+	for i, embed := range embeds {
+		embed.Title = title
+		if len(embeds) > 1 {
+			embed.Title = fmt.Sprintf("%s (%d/%d)", title, i+1, len(embeds))
+		}
+	}
+
+	return messages
+}
+
+// digestLine summarizes a job for a digest: a link titled with its company and role, then its details.
+func digestLine(match *subscriptionMatch) string {
+	job := &match.job
+
+	name := job.Company + " — " + job.Role
+	details := []string{}
+	if job.Location != "" {
+		details = append(details, job.Location)
+	}
+	details = append(details, fmt.Sprintf("<t:%d:R>", job.FirstSeen.Unix()), strings.Join(match.handles, ", "))
+
+	line := fmt.Sprintf("**[%s](%s)**\n%s", name, job.ApplicationLink, strings.Join(details, " · "))
+	if utf8.RuneCountInString(line) > digestLineChars {
+		// Long roles or location lists are cut short rather than the link, which the line is useless without.
+		line = fmt.Sprintf("**[%s](%s)**\n%s", commands.Truncate(name, 150), job.ApplicationLink, strings.Join(details[len(details)-2:], " · "))
+	}
+
+	return line
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/google/uuid"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a migrated database that only lasts for the test.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.Migrate(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestFindJobMessages(t *testing.T) {
+	db := newTestDB(t)
+
+	statuses := []struct {
+		status       models.JobStatus
+		markedClosed bool
+		stale        bool
+	}{
+		{models.JobStatusOpen, false, false},
+		{models.JobStatusClosed, false, true},
+		{models.JobStatusClosed, true, false},
+		{models.JobStatusReopened, true, true},
+		{models.JobStatusReopened, false, false},
+	}
+
+	want := map[uuid.UUID]bool{}
+	for i, status := range statuses {
+		job := models.Job{Company: "Acme", ApplicationLink: fmt.Sprintf("https://jobs.example.com/%d", i), FirstSeen: time.Now(), Status: status.status}
+		err := db.Create(&job).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sentJob := models.SentJob{GuildID: uuid.New(), JobID: job.ID, MessageId: "message", ChannelID: "channel"}
+		err = db.Create(&sentJob).Error
+		if err == nil {
+			err = db.Model(&sentJob).Update("marked_closed", status.markedClosed).Error
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		delivery := models.SubscriptionDelivery{UserID: "user", JobID: job.ID, MessageID: "message", ChannelID: "dm", Digest: true, MarkedClosed: status.markedClosed}
+		err = db.Create(&delivery).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want[sentJob.ID] = status.stale
+		want[delivery.ID] = status.stale
+	}
+
+	messages, err := findJobMessages(nil, db, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[uuid.UUID]bool{}
+	for _, message := range messages {
+		found[message.id] = true
+		if message.table == "subscription_deliveries" && !message.digest {
+			t.Errorf("expected delivery %s to be found as a digest", message.id)
+		}
+	}
+	for id, stale := range want {
+		if found[id] != stale {
+			t.Errorf("message %s found = %t, want %t", id, found[id], stale)
+		}
+	}
+}
+
+func TestMarkJobMessageKeepsFields(t *testing.T) {
+	job := models.Job{
+		Company:         "Acme",
+		Role:            "Software Engineer Intern",
+		Location:        "New York, NY",
+		ApplicationLink: "https://jobs.example.com/acme/1",
+		FirstSeen:       time.Now(),
+		Status:          models.JobStatusOpen,
+	}
+
+	sent := GenerateMessage(&job)
+	sent.Embeds[0].Fields = append(sent.Embeds[0].Fields, &discordgo.MessageEmbedField{Name: "Matched", Value: "quant"})
+
+	job.Status = models.JobStatusClosed
+	closed := markJobMessage(sent.Embeds, &job)
+
+	names := []string{}
+	for _, field := range closed.Embeds[0].Fields {
+		names = append(names, field.Name)
+	}
+	if want := []string{"Role", "Location", "Closed", "Matched"}; !slices.Equal(names, want) {
+		t.Errorf("closed message fields = %v, want %v", names, want)
+	}
+	button := closed.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+	if !button.Disabled {
+		t.Error("expected Apply to be disabled once the job closed")
+	}
+
+	job.Status = models.JobStatusReopened
+	reopened := markJobMessage(closed.Embeds, &job)
+
+	names = names[:0]
+	for _, field := range reopened.Embeds[0].Fields {
+		names = append(names, field.Name)
+	}
+	if want := []string{"Role", "Location", "Matched"}; !slices.Equal(names, want) {
+		t.Errorf("reopened message fields = %v, want %v", names, want)
+	}
+	button = reopened.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+	if button.Disabled {
+		t.Error("expected Apply to be enabled again once the job reopened")
+	}
+}
+
+func TestMarkDigestJob(t *testing.T) {
+	jobs := []models.Job{
+		{Company: "Acme", Role: "Intern", ApplicationLink: "https://jobs.example.com/1", FirstSeen: time.Unix(1000, 0)},
+		{Company: "Globex", Role: "Intern", ApplicationLink: "https://jobs.example.com/10", FirstSeen: time.Unix(2000, 0)},
+	}
+	matches := []*subscriptionMatch{}
+	for _, job := range jobs {
+		matches = append(matches, &subscriptionMatch{job: job, handles: []string{"quant"}})
+	}
+
+	embeds := digestMessages("Daily Digest", matches)[0].message.Embeds
+	sent := embeds[0].Description
+
+	jobs[0].Status = models.JobStatusClosed
+	embeds = markDigestJob(embeds, &jobs[0])
+
+	heading, details, _ := strings.Cut(digestLine(matches[0]), "\n")
+	if want := "~~" + heading + "~~\n" + details + "\n" + digestLine(matches[1]); embeds[0].Description != want {
+		t.Errorf("expected only the closed job's heading to be struck through, got:\n%s", embeds[0].Description)
+	}
+
+	jobs[0].Status = models.JobStatusReopened
+	embeds = markDigestJob(embeds, &jobs[0])
+
+	if embeds[0].Description != sent {
+		t.Errorf("expected the digest to be restored once the job reopened, got:\n%s", embeds[0].Description)
+	}
+}
+
+func newDigestMatches(n int, role string) []*subscriptionMatch {
+	matches := []*subscriptionMatch{}
+	for i := range n {
+		matches = append(matches, &subscriptionMatch{
+			job: models.Job{
+				Company:         "Acme",
+				Role:            role,
+				Location:        "New York, NY",
+				ApplicationLink: fmt.Sprintf("https://jobs.example.com/acme/%d", i),
+				FirstSeen:       time.Unix(int64(1789135200+i), 0),
+			},
+			handles: []string{"quant", "swe"},
+		})
+	}
+	return matches
+}
+
+func TestDigestMessagesSingle(t *testing.T) {
+	matches := newDigestMatches(3, "Software Engineer Intern")
+
+	messages := digestMessages("Daily Digest", matches)
+
+	if len(messages) != 1 || len(messages[0].message.Embeds) != 1 || len(messages[0].matches) != 3 {
+		t.Fatalf("expected a single embed listing every match, got %+v", messages)
+	}
+
+	embed := messages[0].message.Embeds[0]
+	if embed.Title != "Daily Digest" {
+		t.Errorf("expected an unnumbered title, got %q", embed.Title)
+	}
+	lines := []string{}
+	for _, match := range matches {
+		lines = append(lines, digestLine(match))
+	}
+	if embed.Description != strings.Join(lines, "\n") {
+		t.Errorf("expected one entry per job, got:\n%s", embed.Description)
+	}
+}
+
+func TestDigestMessagesLimits(t *testing.T) {
+	matches := newDigestMatches(400, "Software Engineer Intern, Infrastructure and Developer Productivity")
+
+	messages := digestMessages("Weekly Digest", matches)
+
+	if len(messages) < 2 {
+		t.Fatalf("expected the matches to need several messages, got %d", len(messages))
+	}
+
+	listed := []*subscriptionMatch{}
+	embeds := 0
+	for _, message := range messages {
+		listed = append(listed, message.matches...)
+
+		if len(message.message.Embeds) > digestEmbedsPerMessage {
+			t.Errorf("message has %d embeds, more than %d", len(message.message.Embeds), digestEmbedsPerMessage)
+		}
+
+		chars := 0
+		lines := 0
+		for _, embed := range message.message.Embeds {
+			embeds++
+			length := utf8.RuneCountInString(embed.Description)
+			lines += strings.Count(embed.Description, "\n**[") + 1
+			if length+digestClosedChars*lines > digestCharsPerEmbed {
+				t.Errorf("embed %q has %d characters, more than %d once its jobs close", embed.Title, length, digestCharsPerEmbed)
+			}
+			chars += utf8.RuneCountInString(embed.Title) + length
+		}
+		if chars+digestClosedChars*len(message.matches) > digestCharsPerMessage {
+			t.Errorf("message has %d characters, more than %d once its jobs close", chars, digestCharsPerMessage)
+		}
+	}
+
+	if len(listed) != len(matches) {
+		t.Fatalf("expected every match to be listed once, got %d of %d", len(listed), len(matches))
+	}
+	for i := range matches {
+		if listed[i] != matches[i] {
+			t.Fatalf("expected the matches in order, got a different match at %d", i)
+		}
+	}
+
+	last := messages[len(messages)-1].message.Embeds
+	if want := fmt.Sprintf("Weekly Digest (%d/%d)", embeds, embeds); last[len(last)-1].Title != want {
+		t.Errorf("expected the last embed to be titled %q, got %q", want, last[len(last)-1].Title)
+	}
+}
+
+func TestDigestLineShortensLongEntries(t *testing.T) {
+	match := newDigestMatches(1, strings.Repeat("Software Engineer Intern ", 40))[0]
+	match.job.Location = strings.Repeat("New York, NY, ", 40)
+
+	line := digestLine(match)
+
+	if utf8.RuneCountInString(line) > digestLineChars {
+		t.Errorf("expected the line to be at most %d characters, got %d", digestLineChars, utf8.RuneCountInString(line))
+	}
+	if !strings.Contains(line, "("+match.job.ApplicationLink+")") || !strings.HasSuffix(line, "quant, swe") {
+		t.Errorf("expected the link and matched subscriptions to be kept, got %q", line)
+	}
+}
+
+func TestDigestTitle(t *testing.T) {
+	daily := &models.Subscription{Delivery: models.DeliveryModeDaily}
+	weekly := &models.Subscription{Delivery: models.DeliveryModeWeekly}
+
+	tests := []struct {
+		subscriptions []*models.Subscription
+		want          string
+	}{
+		{[]*models.Subscription{daily}, "Daily Digest"},
+		{[]*models.Subscription{weekly, weekly}, "Weekly Digest"},
+		{[]*models.Subscription{daily, weekly}, "Internly Digest"},
+	}
+
+	for _, test := range tests {
+		if got := digestTitle(test.subscriptions); got != test.want {
+			t.Errorf("digestTitle() = %q, want %q", got, test.want)
+		}
+	}
+}
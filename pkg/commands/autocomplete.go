@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	maxChoices      = 25
+	maxChoiceLength = 100
+	// suggestionTTL is how long the values in the jobs table are cached, since they are read on every keystroke.
+	suggestionTTL = 10 * time.Minute
+)
+
+// suggestion is a distinct value of a job field, along with how many jobs have it.
+type suggestion struct {
+	value string
+	count int
+}
+
+// suggestions caches the distinct companies and locations in the jobs table, ordered by how many jobs have them.
+type suggestions struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	expires   time.Time
+	companies []suggestion
+	locations []suggestion
+}
+
+func newSuggestions(db *gorm.DB) *suggestions {
+	return &suggestions{db: db}
+}
+
+func (s *suggestions) get(column string) ([]suggestion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().After(s.expires) {
+		companies, err := s.load("company", false)
+		if err != nil {
+			return nil, err
+		}
+		// A job's location is often a list, so each location in it is suggested separately.
+		locations, err := s.load("location", true)
+		if err != nil {
+			return nil, err
+		}
+		s.companies, s.locations = companies, locations
+		s.expires = time.Now().Add(suggestionTTL)
+	}
+
+	if column == "location" {
+		return s.locations, nil
+	}
+	return s.companies, nil
+}
+
+// load reads the distinct values of a column. Values differing only by case or spacing are merged, and shown as
+// they are most commonly written.
+func (s *suggestions) load(column string, split bool) ([]suggestion, error) {
+	var rows []struct {
+		Value string
+		Count int
+	}
+	err := s.db.Model(&models.Job{}).
+		Select(column + " AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " != ''").
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type spelling struct {
+		total  int
+		best   string
+		counts map[string]int
+	}
+
+	merged := map[string]*spelling{}
+	keys := []string{}
+
+	add := func(value string, count int) {
+		value = strings.Join(strings.Fields(value), " ")
+		if value == "" || strings.ContainsRune(value, ',') || len(value) > maxChoiceLength {
+			return
+		}
+		key := strings.ToLower(value)
+		m, ok := merged[key]
+		if !ok {
+			m = &spelling{counts: map[string]int{}}
+			merged[key] = m
+			keys = append(keys, key)
+		}
+		m.total += count
+		m.counts[value] += count
+		if m.counts[value] > m.counts[m.best] {
+			m.best = value
+		}
+	}
+
+	for _, row := range rows {
+		if !split {
+			add(row.Value, row.Count)
+			continue
+		}
+		for _, part := range strings.FieldsFunc(row.Value, func(r rune) bool { return r == ',' || r == ';' || r == '|' }) {
+			add(part, row.Count)
+		}
+	}
+
+	values := make([]suggestion, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, suggestion{value: merged[key].best, count: merged[key].total})
+	}
+
+	slices.SortStableFunc(values, func(a, b suggestion) int {
+		return b.count - a.count
+	})
+
+	return values, nil
+}
+
+// rank orders the values matching query, best first. Prefix and substring matches come before values that are only
+// close to the query, such as "Goolge" for "Google", and ties go to the value more jobs have.
+func rank(values []suggestion, query string, exclude map[string]bool) []string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+
+	type ranked struct {
+		suggestion
+		tier     int
+		distance int
+	}
+
+	matches := []ranked{}
+	for _, value := range values {
+		lower := strings.ToLower(value.value)
+		if exclude[lower] {
+			continue
+		}
+
+		tier, distance, ok := matchTier(lower, query)
+		if !ok {
+			continue
+		}
+		matches = append(matches, ranked{suggestion: value, tier: tier, distance: distance})
+	}
+
+	slices.SortStableFunc(matches, func(a, b ranked) int {
+		if a.tier != b.tier {
+			return a.tier - b.tier
+		}
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return b.count - a.count
+	})
+
+	ranks := make([]string, len(matches))
+	for j, match := range matches {
+		ranks[j] = match.value
+	}
+	return ranks
+}
+
+// matchTier reports how well value matches query, where a lower tier is a better match:
+// 0 is exact, 1 a prefix, 2 a prefix of a later word, 3 a substring and 4 within a few typos of a prefix.
+func matchTier(value string, query string) (int, int, bool) {
+	switch {
+	case query == "" || value == query:
+		return 0, 0, true
+	case strings.HasPrefix(value, query):
+		return 1, 0, true
+	case strings.Contains(value, " "+query):
+		return 2, 0, true
+	case strings.Contains(value, query):
+		return 3, 0, true
+	}
+
+	// Short queries allow fewer typos, since almost anything is a couple of edits away from two letters.
+	allowed := 0
+	switch {
+	case len(query) >= 8:
+		allowed = 2
+	case len(query) >= 4:
+		allowed = 1
+	}
+	if allowed == 0 {
+		return 0, 0, false
+	}
+
+	best := allowed + 1
+	for _, word := range append([]string{value}, strings.Fields(value)[1:]...) {
+		runes := []rune(word)
+		// The query is compared with prefixes around its own length, so that a word still being typed matches.
+		for _, n := range []int{len([]rune(query)) - 1, len([]rune(query)), len([]rune(query)) + 1} {
+			if n > 0 && n <= len(runes) {
+				best = min(best, editDistance(string(runes[:n]), query))
+			}
+		}
+	}
+	if best > allowed {
+		return 0, 0, false
+	}
+	return 4, best, true
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of adjacent characters that turn a into b.
+func editDistance(a string, b string) int {
+	x, y := []rune(a), []rune(b)
+
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(x)][len(y)]
+}
+
+// RunListAutocomplete suggests companies and locations for comma separated list options. Only the last item in the
+// list is completed, so each choice is the list typed so far with a suggestion in place of its last item.
+func RunListAutocomplete(log *zap.SugaredLogger, db *gorm.DB, columns map[string]string) CommandExecutor {
+	cache := newSuggestions(db)
+
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		focused := focusedOption(i.ApplicationCommandData().Options)
+
+		choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+		column, ok := "", false
+		if focused != nil {
+			column, ok = columns[focused.Name]
+		}
+
+		if ok {
+			values, err := cache.get(column)
+			if err != nil {
+				log.Errorf("Error loading %s suggestions: %v", column, err)
+			}
+
+			items := strings.Split(focused.StringValue(), ",")
+			last := strings.TrimSpace(items[len(items)-1])
+
+			typed := []string{}
+			exclude := map[string]bool{}
+			for _, item := range items[:len(items)-1] {
+				item = strings.TrimSpace(item)
+				if item != "" {
+					typed = append(typed, item)
+					exclude[strings.ToLower(item)] = true
+				}
+			}
+
+			for _, value := range rank(values, last, exclude) {
+				choice := strings.Join(append(slices.Clone(typed), value), ", ")
+				if len(choice) > maxChoiceLength {
+					continue
+				}
+				choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+					Name:  choice,
+					Value: choice,
+				})
+				if len(choices) == maxChoices {
+					break
+				}
+			}
+		}
+
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{
+				Choices: choices,
+			},
+		})
+		if err != nil {
+			log.Errorf("Error responding to autocomplete: %v", err)
+		}
+	}
+}
+
+// focusedOption returns the option being typed in, looking through subcommands, or nil if there is none.
+func focusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, option := range options {
+		if option.Focused {
+			return option
+		}
+		if focused := focusedOption(option.Options); focused != nil {
+			return focused
+		}
+	}
+	return nil
+}
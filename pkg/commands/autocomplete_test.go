@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"google", "google", 0},
+		{"", "meta", 4},
+		{"goolge", "google", 1},
+		{"googl", "google", 1},
+		{"gogle", "google", 1},
+		{"gaagle", "google", 2},
+		{"stripe", "strip", 1},
+		{"café", "cafe", 1},
+		{"jane street", "jane stret", 1},
+	}
+
+	for _, test := range tests {
+		if got := editDistance(test.a, test.b); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := editDistance(test.b, test.a); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", test.b, test.a, got, test.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	values := []suggestion{
+		{value: "Goldman Sachs", count: 40},
+		{value: "Google", count: 30},
+		{value: "Alphabet Google Labs", count: 20},
+		{value: "Big Goose", count: 15},
+		{value: "Golden Gate", count: 10},
+		{value: "New York, NY", count: 8},
+		{value: "Go", count: 5},
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		exclude map[string]bool
+		want    []string
+	}{
+		{
+			name:  "empty query keeps the most common first",
+			query: "",
+			want:  []string{"Goldman Sachs", "Google", "Alphabet Google Labs", "Big Goose", "Golden Gate", "New York, NY", "Go"},
+		},
+		{
+			name:  "exact before prefixes, by job count",
+			query: "go",
+			want:  []string{"Go", "Goldman Sachs", "Google", "Golden Gate", "Alphabet Google Labs", "Big Goose"},
+		},
+		{
+			name:  "word prefixes before substrings",
+			query: "oo",
+			want:  []string{"Google", "Alphabet Google Labs", "Big Goose"},
+		},
+		{
+			name:  "case and spacing are ignored",
+			query: "  GOOGLE ",
+			want:  []string{"Google", "Alphabet Google Labs"},
+		},
+		{
+			name:  "typos match values within a few edits",
+			query: "goolge",
+			want:  []string{"Google", "Alphabet Google Labs"},
+		},
+		{
+			name:  "short queries allow no typos",
+			query: "gx",
+			want:  []string{},
+		},
+		{
+			name:    "excluded values are left out",
+			query:   "go",
+			exclude: map[string]bool{"google": true, "go": true},
+			want:    []string{"Goldman Sachs", "Golden Gate", "Alphabet Google Labs", "Big Goose"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := rank(values, test.query, test.exclude)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("rank(%q) = %q, want %q", test.query, got, test.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,13 @@ type Command struct {
 	Command    *discordgo.ApplicationCommand
 	GuildsOnly bool
 	Executor   CommandExecutor
+	// Autocomplete suggests values for the command's options as they are typed.
+	Autocomplete CommandExecutor
+	// Components and Modals handle the select menus, buttons and modals a command responds with, keyed by the custom
+	// ID up to the first ':'. Anything after the ':', such as the ID of the subscription being edited, is left for
+	// the handler to read.
+	Components map[string]CommandExecutor
+	Modals     map[string]CommandExecutor
 }
 
 func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -17,52 +19,142 @@ func RunConfigureCommand(db *gorm.DB) CommandExecutor {
 				Flags: discordgo.MessageFlagsEphemeral,
 			},
 		})
-		var guild models.Guild
 
-		err := db.Where("guild_id = ?", i.GuildID).First(&guild).Error
+		data := i.ApplicationCommandData()
+		if len(data.Options) == 0 {
+			return
+		}
+		subcommand := data.Options[0]
+
+		options := commandOptions{}
+		for _, option := range subcommand.Options {
+			options[option.Name] = option
+		}
+
+		guild, err := findGuild(db, i.GuildID)
 		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				guild.GuildID = i.GuildID
-				guild.InternChannelID = i.ApplicationCommandData().Options[0].StringValue()
-				guild.NewGradChannelID = i.ApplicationCommandData().Options[1].StringValue()
-				db.Create(&guild)
-			} else {
-				s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-					Embeds: []*discordgo.MessageEmbed{
-						{
-							Title:       "Error",
-							Description: "Something went wrong",
-							Color:       0xff0000,
-							Timestamp:   time.Now().Format(time.RFC3339),
-							Author: &discordgo.MessageEmbedAuthor{
-								Name: "Internly Bot",
-								URL:  "https://github.com/stephensulimani/internly-bot",
-							},
-						},
-					},
-				})
+			configureResult(s, i, "Error", "Something went wrong", 0xff0000)
+			return
+		}
+
+		switch subcommand.Name {
+		case "channels":
+			configureChannels(db, s, i, guild, options)
+		case "schedule":
+			configureSchedule(db, s, i, guild, options)
+		}
+	}
+}
+
+func configureChannels(db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	internChannelID := options["intern-channel"].ChannelValue(nil).ID
+	newGradChannelID := options["new-grad-channel"].ChannelValue(nil).ID
+
+	err := saveDefaultFeed(db, guild, models.FeedNameInterns, internChannelID, models.INTERN)
+	if err == nil {
+		err = saveDefaultFeed(db, guild, models.FeedNameNewGrads, newGradChannelID, models.NEW_GRAD)
+	}
+	if err != nil {
+		configureResult(s, i, "Error", "Something went wrong", 0xff0000)
+		return
+	}
+
+	configureResult(s, i, "Success", fmt.Sprintf("Channels were successfully configured\nIntern Channel: <#%s>\nNew Grad Channel: <#%s>\n\nUse `/feed` to post jobs matching other filters to more channels.", internChannelID, newGradChannelID), 0x00ff00)
+}
+
+// configureSchedule sets the guild's timezone, quiet hours and batch times. Options that aren't given are left as
+// they are, and quiet hours and batch times set to off are cleared.
+func configureSchedule(db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	if option, ok := options["timezone"]; ok {
+		timezone := strings.TrimSpace(option.StringValue())
+		_, err := time.LoadLocation(timezone)
+		if err != nil || timezone == "" || timezone == "Local" {
+			configureResult(s, i, "Error", fmt.Sprintf("`%s` is not a timezone Internly recognizes. Please use a name such as `America/New_York` or `Europe/London`.", timezone), 0xff0000)
+			return
+		}
+		guild.Timezone = timezone
+	}
+
+	if option, ok := options["quiet-hours"]; ok {
+		value := strings.TrimSpace(option.StringValue())
+		if strings.EqualFold(value, "off") {
+			guild.QuietStart, guild.QuietEnd = "", ""
+		} else {
+			start, end, _ := strings.Cut(value, "-")
+			startMinute, startOK := models.ParseClock(start)
+			endMinute, endOK := models.ParseClock(end)
+			if !startOK || !endOK || startMinute == endMinute {
+				configureResult(s, i, "Error", fmt.Sprintf("`%s` are not quiet hours Internly understands. Please give a start and end time such as `22:00-07:00`, or `off`.", value), 0xff0000)
 				return
 			}
-		} else {
-			guild.InternChannelID = i.ApplicationCommandData().Options[0].ChannelValue(s).ID
-			guild.NewGradChannelID = i.ApplicationCommandData().Options[1].ChannelValue(s).ID
-			db.Save(&guild)
+			guild.QuietStart, guild.QuietEnd = models.FormatClock(startMinute), models.FormatClock(endMinute)
 		}
-		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title:       "Success",
-					Description: fmt.Sprintf("Channels were successfully configured\nIntern Channel: <#%s>\nNew Grad Channel: <#%s>", guild.InternChannelID, guild.NewGradChannelID),
-					Color:       0x00ff00,
-					Timestamp:   time.Now().Format(time.RFC3339),
-					Author: &discordgo.MessageEmbedAuthor{
-						Name: "Internly Bot",
-						URL:  "https://github.com/stephensulimani/internly-bot",
-					},
+	}
+
+	if option, ok := options["batch-times"]; ok {
+		value := strings.TrimSpace(option.StringValue())
+		batchTimes := models.StringSlice{}
+		if !strings.EqualFold(value, "off") {
+			minutes := []int{}
+			for _, batchTime := range strings.Split(value, ",") {
+				minute, ok := models.ParseClock(batchTime)
+				if !ok {
+					configureResult(s, i, "Error", fmt.Sprintf("`%s` is not a time Internly understands. Please give times such as `09:00, 17:00`, or `off`.", strings.TrimSpace(batchTime)), 0xff0000)
+					return
+				}
+				minutes = append(minutes, minute)
+			}
+			slices.Sort(minutes)
+			for _, minute := range slices.Compact(minutes) {
+				batchTimes = append(batchTimes, models.FormatClock(minute))
+			}
+		}
+		guild.BatchTimes = batchTimes
+
+		// Jobs already waiting are posted at the next batch time, rather than as soon as batching is turned on.
+		now := time.Now()
+		guild.LastBatchAt = &now
+	}
+
+	err := db.Model(guild).Select("Timezone", "QuietStart", "QuietEnd", "BatchTimes", "LastBatchAt").Updates(guild).Error
+	if err != nil {
+		configureResult(s, i, "Error", "Something went wrong", 0xff0000)
+		return
+	}
+
+	configureResult(s, i, "Success", "The posting schedule was successfully configured\n"+describeSchedule(guild), 0x00ff00)
+}
+
+// describeSchedule lists a guild's timezone, quiet hours and batch times.
+func describeSchedule(guild *models.Guild) string {
+	quietHours := "None"
+	if guild.QuietStart != "" && guild.QuietEnd != "" {
+		quietHours = fmt.Sprintf("%s to %s", guild.QuietStart, guild.QuietEnd)
+	}
+
+	batchTimes := "None, jobs are posted as soon as they are found"
+	if guild.Batched() {
+		batchTimes = guild.BatchTimes.String()
+	}
+
+	return fmt.Sprintf("Timezone: %s\nQuiet Hours: %s\nBatch Times: %s", guild.Location(), quietHours, batchTimes)
+}
+
+func configureResult(s *discordgo.Session, i *discordgo.InteractionCreate, title string, description string, color int) {
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       title,
+				Description: description,
+				Color:       color,
+				Timestamp:   time.Now().Format(time.RFC3339),
+				Author: &discordgo.MessageEmbedAuthor{
+					Name: "Internly Bot",
+					URL:  "https://github.com/stephensulimani/internly-bot",
 				},
 			},
-		})
-	}
+		},
+	})
 }
 
 func ConfigureCommand(db *gorm.DB) Command {
@@ -74,16 +166,48 @@ func ConfigureCommand(db *gorm.DB) Command {
 			DefaultMemberPermissions: &manageChannels,
 			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Type:        discordgo.ApplicationCommandOptionChannel,
-					Name:        "intern-channel",
-					Description: "The channel for internships",
-					Required:    true,
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "channels",
+					Description: "Set the channels internships and new grad positions are posted to",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionChannel,
+							Name:        "intern-channel",
+							Description: "The channel for internships",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionChannel,
+							Name:        "new-grad-channel",
+							Description: "The channel for new-grad positions",
+							Required:    true,
+						},
+					},
 				},
 				{
-					Type:        discordgo.ApplicationCommandOptionChannel,
-					Name:        "new-grad-channel",
-					Description: "The channel for new-grad positions",
-					Required:    true,
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "schedule",
+					Description: "Set when jobs are posted, holding them until then",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "timezone",
+							Description: "Timezone of the times below, e.g. America/New_York (defaults to UTC)",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "quiet-hours",
+							Description: "Times not to post between, e.g. 22:00-07:00, or off",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "batch-times",
+							Description: "Only post at these times, e.g. 09:00, 17:00, or off to post right away",
+							Required:    false,
+						},
+					},
 				},
 			},
 		},
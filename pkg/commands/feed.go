@@ -0,0 +1,598 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/filter"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxFeeds is the most feeds a guild can have, which is as many as /feed list can show in one embed.
+const maxFeeds = 25
+
+// maxForumTags is the most tags Discord allows a forum channel to have.
+const maxForumTags = 20
+
+type commandOptions map[string]*discordgo.ApplicationCommandInteractionDataOption
+
+// filterListColumns are the job columns suggested for the filter options with autocomplete.
+var filterListColumns = map[string]string{
+	"companies":         "company",
+	"locations":         "location",
+	"exclude-companies": "company",
+	"exclude-locations": "location",
+}
+
+func RunFeedCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+
+		data := i.ApplicationCommandData()
+		if len(data.Options) == 0 {
+			return
+		}
+		subcommand := data.Options[0]
+
+		options := commandOptions{}
+		for _, option := range subcommand.Options {
+			options[option.Name] = option
+		}
+
+		guild, err := findGuild(db, i.GuildID)
+		if err != nil {
+			log.Errorf("Error finding guild %s: %v", i.GuildID, err)
+			followupError(s, i, "Something went wrong")
+			return
+		}
+
+		switch subcommand.Name {
+		case "add":
+			addFeed(log, db, s, i, guild, options)
+		case "list":
+			listFeeds(log, db, s, i, guild)
+		case "remove":
+			removeFeed(log, db, s, i, guild, options)
+		case "edit":
+			editFeed(log, db, s, i, guild, options)
+		}
+	}
+}
+
+func addFeed(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	name := strings.ToLower(strings.TrimSpace(options["name"].StringValue()))
+	if !models.ValidHandle(name) {
+		followupError(s, i, fmt.Sprintf("`%s` can't be used as a name. Names can only have letters, numbers and dashes, and can't be only numbers.", name))
+		return
+	}
+
+	var feeds []models.Feed
+	err := db.Where("guild_id = ?", guild.ID).Find(&feeds).Error
+	if err != nil {
+		log.Errorf("Error finding feeds for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	if len(feeds) >= maxFeeds {
+		followupError(s, i, fmt.Sprintf("This server already has the maximum of %d feeds. Please remove one before adding another.", maxFeeds))
+		return
+	}
+
+	for _, feed := range feeds {
+		if feed.Name == name {
+			followupError(s, i, fmt.Sprintf("This server already has a feed named `%s`. Use `/feed edit` to change it.", name))
+			return
+		}
+	}
+
+	feed := models.Feed{
+		GuildID:   guild.ID,
+		Name:      name,
+		ChannelID: options["channel"].ChannelValue(nil).ID,
+	}
+
+	err = applyFeedOptions(&feed, options)
+	if err != nil {
+		followupError(s, i, fmt.Sprintf("Your query is invalid: %s.\n\nExample: `role:(software OR backend) AND -company:\"Revature\" AND location:(remote OR NYC)`", err))
+		return
+	}
+
+	err = db.Create(&feed).Error
+	if err == gorm.ErrDuplicatedKey {
+		// Another /feed add with the same name was saved since the check above.
+		followupError(s, i, fmt.Sprintf("This server already has a feed named `%s`. Use `/feed edit` to change it.", name))
+		return
+	}
+	if err != nil {
+		log.Errorf("Error saving feed for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	description := "Successfully added the feed. Jobs found from now on that match it will be posted."
+	followupFeed(s, i, description+setupForumTags(log, s, feed.ChannelID), &feed)
+}
+
+func listFeeds(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild) {
+	var feeds []models.Feed
+	err := db.Where("guild_id = ?", guild.ID).Order("created_at ASC").Find(&feeds).Error
+	if err != nil {
+		log.Errorf("Error finding feeds for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	description := ""
+	if len(feeds) == 0 {
+		description = "This server has no feeds. Use `/feed add` to create one."
+	}
+
+	fields := []*discordgo.MessageEmbedField{}
+
+	for _, feed := range feeds {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  feed.Name,
+			Value: describeFeed(&feed),
+		})
+	}
+
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Internly Feeds",
+				Color:       0x00ff00,
+				Description: description,
+				Fields:      fields,
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func removeFeed(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	feed, ok := findFeed(log, db, s, i, guild, options["name"].StringValue())
+	if !ok {
+		return
+	}
+
+	err := db.Delete(feed).Error
+	if err != nil {
+		log.Errorf("Error deleting feed %s for guild %s: %v", feed.Name, guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	followupFeed(s, i, "Successfully removed the feed.", feed)
+}
+
+func editFeed(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	feed, ok := findFeed(log, db, s, i, guild, options["name"].StringValue())
+	if !ok {
+		return
+	}
+
+	if option, ok := options["channel"]; ok {
+		feed.ChannelID = option.ChannelValue(nil).ID
+	}
+
+	err := applyFeedOptions(feed, options)
+	if err != nil {
+		followupError(s, i, fmt.Sprintf("Your query is invalid: %s.\n\nExample: `role:(software OR backend) AND -company:\"Revature\" AND location:(remote OR NYC)`", err))
+		return
+	}
+
+	err = db.Save(feed).Error
+	if err != nil {
+		log.Errorf("Error saving feed %s for guild %s: %v", feed.Name, guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	description := "Successfully updated the feed."
+	if _, ok := options["channel"]; ok {
+		description += setupForumTags(log, s, feed.ChannelID)
+	}
+	followupFeed(s, i, description, feed)
+}
+
+// applyFeedOptions sets the filter and ping of a feed given as options. The error is returned if the query is invalid.
+func applyFeedOptions(feed *models.Feed, options commandOptions) error {
+	err := applyFilterOptions(&feed.Filter, options)
+	if err != nil {
+		return err
+	}
+
+	if option, ok := options["ping"]; ok {
+		feed.RoleID = option.RoleValue(nil, "").ID
+	}
+	if option, ok := options["remove-ping"]; ok && option.BoolValue() {
+		feed.RoleID = ""
+	}
+
+	return nil
+}
+
+// applyFilterOptions sets the job type and filters given as options. Filters set to - are cleared, which is how
+// /feed edit removes them. The error is returned if the query is invalid.
+func applyFilterOptions(f *models.Filter, options commandOptions) error {
+	if option, ok := options["type"]; ok {
+		f.JobType = models.JobType(option.StringValue())
+	}
+
+	lists := map[string]*models.StringSlice{
+		"roles":             &f.Roles,
+		"companies":         &f.Companies,
+		"locations":         &f.Locations,
+		"exclude-roles":     &f.ExcludeRoles,
+		"exclude-companies": &f.ExcludeCompanies,
+		"exclude-locations": &f.ExcludeLocations,
+	}
+
+	for name, list := range lists {
+		option, ok := options[name]
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(option.StringValue())
+		if value == "-" {
+			*list = nil
+		} else {
+			*list = strings.Split(value, ",")
+		}
+	}
+
+	if option, ok := options["query"]; ok {
+		query := strings.TrimSpace(option.StringValue())
+		if query == "-" {
+			query = ""
+		}
+		if query != "" {
+			_, err := filter.Parse(query)
+			if err != nil {
+				return err
+			}
+		}
+		f.Query = query
+	}
+
+	return nil
+}
+
+// setupForumTags adds the tags jobs are posted with to a forum channel, keeping any the forum already has. Nothing is
+// done for other channels. A note for the response is returned when the tags could not all be added.
+func setupForumTags(log *zap.SugaredLogger, s *discordgo.Session, channelID string) string {
+	channel, err := s.Channel(channelID)
+	if err != nil {
+		log.Errorf("Error finding channel %s: %v", channelID, err)
+		return ""
+	}
+	if channel.Type != discordgo.ChannelTypeGuildForum {
+		return ""
+	}
+
+	tags := channel.AvailableTags
+	missing := false
+
+	for _, name := range models.ForumTagNames {
+		exists := slices.ContainsFunc(tags, func(tag discordgo.ForumTag) bool {
+			return strings.EqualFold(tag.Name, name)
+		})
+		if exists {
+			continue
+		}
+		if len(tags) >= maxForumTags {
+			missing = true
+			break
+		}
+		tags = append(tags, discordgo.ForumTag{Name: name})
+	}
+
+	if len(tags) > len(channel.AvailableTags) {
+		_, err = s.ChannelEditComplex(channelID, &discordgo.ChannelEdit{AvailableTags: &tags})
+		if err != nil {
+			log.Errorf("Error adding tags to forum %s: %v", channelID, err)
+			return "\n\nThe forum's tags could not be set up, so jobs will be posted without them. Please give Internly the Manage Channels permission there and edit the feed's channel again."
+		}
+	}
+
+	if missing {
+		return fmt.Sprintf("\n\nThe forum already has %d tags, so some of the tags jobs are posted with could not be added.", maxForumTags)
+	}
+
+	return ""
+}
+
+// findFeed finds the guild's feed with the given name, responding with an error if there is none.
+func findFeed(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, name string) (*models.Feed, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	var feed models.Feed
+	err := db.Where("guild_id = ? AND name = ?", guild.ID, name).First(&feed).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			log.Errorf("Error finding feed %s for guild %s: %v", name, guild.GuildID, err)
+		}
+		followupError(s, i, fmt.Sprintf("This server has no feed named `%s`. Use `/feed list` to see its feeds.", name))
+		return nil, false
+	}
+
+	return &feed, true
+}
+
+// findGuild finds a guild, creating it if the bot hasn't recorded it yet.
+func findGuild(db *gorm.DB, guildID string) (*models.Guild, error) {
+	var guild models.Guild
+
+	err := db.Where("guild_id = ?", guildID).First(&guild).Error
+	if err == gorm.ErrRecordNotFound {
+		guild.GuildID = guildID
+		err = db.Create(&guild).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &guild, nil
+}
+
+// saveDefaultFeed points one of the feeds /configure channels manages at a channel, creating the feed if needed.
+func saveDefaultFeed(db *gorm.DB, guild *models.Guild, name string, channelID string, jobType models.JobType) error {
+	var feed models.Feed
+
+	err := db.Where("guild_id = ? AND name = ?", guild.ID, name).First(&feed).Error
+	if err == gorm.ErrRecordNotFound {
+		return db.Create(&models.Feed{
+			GuildID:   guild.ID,
+			Name:      name,
+			ChannelID: channelID,
+			Filter:    models.Filter{JobType: jobType},
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	feed.ChannelID = channelID
+	return db.Save(&feed).Error
+}
+
+// describeFeed lists a feed's channel, filters and ping for an embed field.
+func describeFeed(feed *models.Feed) string {
+	description := fmt.Sprintf("Channel: <#%s>\n%s", feed.ChannelID, describeFilter(&feed.Filter))
+	if feed.RoleID != "" {
+		description += fmt.Sprintf("\nPing: <@&%s>", feed.RoleID)
+	}
+	return description
+}
+
+func followupFeed(s *discordgo.Session, i *discordgo.InteractionCreate, description string, feed *models.Feed) {
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Internly Feeds",
+				Color:       0x00ff00,
+				Description: description,
+				Fields: []*discordgo.MessageEmbedField{
+					{
+						Name:  feed.Name,
+						Value: describeFeed(feed),
+					},
+				},
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// RunFeedAutocomplete suggests the guild's feed names for the name option, and companies and locations otherwise.
+func RunFeedAutocomplete(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	lists := RunListAutocomplete(log, db, filterListColumns)
+
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		focused := focusedOption(i.ApplicationCommandData().Options)
+		if focused == nil || focused.Name != "name" {
+			lists(s, i)
+			return
+		}
+
+		typed := strings.ToLower(strings.TrimSpace(focused.StringValue()))
+
+		var feeds []models.Feed
+		err := db.Table("feeds").
+			Select("feeds.*").
+			Joins("JOIN guilds ON guilds.id = feeds.guild_id").
+			Where("guilds.guild_id = ?", i.GuildID).
+			Order("feeds.created_at ASC").
+			Find(&feeds).Error
+		if err != nil {
+			log.Errorf("Error finding feeds for guild %s: %v", i.GuildID, err)
+		}
+
+		choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+		for _, feed := range feeds {
+			if !strings.Contains(feed.Name, typed) {
+				continue
+			}
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  feed.Name,
+				Value: feed.Name,
+			})
+		}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{
+				Choices: choices,
+			},
+		})
+		if err != nil {
+			log.Errorf("Error responding to autocomplete: %v", err)
+		}
+	}
+}
+
+// feedOptions are the options of /feed add and /feed edit after the feed's name, which only /feed add requires.
+func feedOptions(required bool) []*discordgo.ApplicationCommandOption {
+	options := []*discordgo.ApplicationCommandOption{
+		{
+			Type:         discordgo.ApplicationCommandOptionChannel,
+			Name:         "channel",
+			Description:  "Channel to post jobs in, where forums get a thread per job",
+			Required:     required,
+			ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews, discordgo.ChannelTypeGuildForum},
+		},
+	}
+
+	options = append(options, filterOptions(required, !required)...)
+
+	options = append(options, &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionRole,
+		Name:        "ping",
+		Description: "Role to mention with each job posted, within the channel's hourly ping limit",
+		Required:    false,
+	})
+
+	if !required {
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "remove-ping",
+			Description: "Stop mentioning a role with every job posted",
+			Required:    false,
+		})
+	}
+
+	return options
+}
+
+// filterOptions are the job type, filter and query options applied by applyFilterOptions. Filters that can be cleared
+// say how in their descriptions.
+func filterOptions(typeRequired bool, clearable bool) []*discordgo.ApplicationCommandOption {
+	options := []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "type",
+			Description: "Type of posting",
+			Required:    typeRequired,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:  "New Grad Position",
+					Value: "NEW_GRAD",
+				},
+				{
+					Name:  "Internship",
+					Value: "INTERN",
+				},
+			},
+		},
+	}
+
+	clear := ""
+	if clearable {
+		clear = ", or - to clear"
+	}
+
+	lists := []struct {
+		name         string
+		description  string
+		autocomplete bool
+	}{
+		{"roles", "Roles to post, separated with commas", false},
+		{"companies", "Companies to post, separated with commas", true},
+		{"locations", "Locations to post, separated with commas", true},
+		{"exclude-roles", "Roles to leave out, separated with commas", false},
+		{"exclude-companies", "Companies to leave out, separated with commas", true},
+		{"exclude-locations", "Locations to leave out, separated with commas", true},
+	}
+
+	for _, list := range lists {
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         list.name,
+			Description:  list.description + clear,
+			Required:     false,
+			Autocomplete: list.autocomplete,
+		})
+	}
+
+	return append(options, &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "query",
+		Description: "Advanced search, e.g. role:(software OR backend)" + clear,
+		Required:    false,
+		MaxLength:   500,
+	})
+}
+
+func FeedCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
+	var manageChannels int64 = discordgo.PermissionManageChannels
+	return Command{
+		Command: &discordgo.ApplicationCommand{
+			Name:                     "feed",
+			Description:              "Manage the channels jobs are posted to",
+			DefaultMemberPermissions: &manageChannels,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Post jobs matching a filter to a channel",
+					Options: append([]*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "name",
+							Description: "Name to refer to the feed by, e.g. faang-only",
+							Required:    true,
+							MaxLength:   32,
+						},
+					}, feedOptions(true)...),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List this server's feeds",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Stop posting a feed",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "name",
+							Description:  "Name of the feed to remove",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "edit",
+					Description: "Change a feed's channel, filters or ping",
+					Options: append([]*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "name",
+							Description:  "Name of the feed to edit",
+							Required:     true,
+							Autocomplete: true,
+						},
+					}, feedOptions(false)...),
+				},
+			},
+		},
+		GuildsOnly:   true,
+		Executor:     RunFeedCommand(log, db),
+		Autocomplete: RunFeedAutocomplete(log, db),
+	}
+}
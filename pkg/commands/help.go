@@ -4,10 +4,10 @@ import "github.com/bwmarrin/discordgo"
 
 func RunHelpCommand() CommandExecutor {
 	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		description := "`/subscribe` - Subscribes to job postings\n`/unsubscribe` - Unsubscribes from job postings\n`/subscriptions` - Lists your subscriptions\n`/help` - Displays this help menu"
+		description := "`/subscribe` - Subscribes to job postings\n`/unsubscribe` - Unsubscribes from job postings\n`/subscriptions` - Lists your subscriptions\n`/subscription edit` - Edits one of your subscriptions\n`/pause` - Pauses your subscriptions\n`/resume` - Resumes your paused subscriptions\n`/help` - Displays this help menu"
 
 		if i.Member.Permissions&discordgo.PermissionManageChannels != 0 {
-			description += "\n`/configure` - Configures the bot"
+			description += "\n`/configure` - Configures the bot's channels and posting schedule\n`/feed` - Manages the channels jobs are posted to\n`/ping` - Mentions roles with the posted jobs that match their filters"
 		}
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
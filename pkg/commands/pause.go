@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RunPauseCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+		var user_id string
+
+		if i.Member != nil {
+			user_id = i.Member.User.ID
+		} else {
+			user_id = i.User.ID
+		}
+
+		id := ""
+		until := ""
+		skip := false
+
+		for _, option := range i.ApplicationCommandData().Options {
+			switch option.Name {
+			case "id":
+				id = option.StringValue()
+			case "until":
+				until = strings.TrimSpace(option.StringValue())
+			case "missed":
+				skip = option.StringValue() == "skip"
+			}
+		}
+
+		var subscriptions []models.Subscription
+
+		err := db.Where("user_id = ? AND deleted_at IS NULL", user_id).Order("created_at DESC").Find(&subscriptions).Error
+		if err != nil {
+			log.Errorf("Error finding subscriptions for user %s: %v", user_id, err)
+			followupError(s, i, "Something went wrong")
+			return
+		}
+
+		selected, ok := selectSubscriptions(subscriptions, id)
+		if !ok {
+			followupError(s, i, "Invalid ID")
+			return
+		}
+
+		// Every date is checked before any subscription is paused, so that an invalid one doesn't pause only some of them.
+		resumeAts := make([]*time.Time, len(selected))
+		if until != "" {
+			for j, subscription := range selected {
+				// The date is read in the subscription's timezone, so that it resumes at the start of that day for the user.
+				date, err := time.ParseInLocation(time.DateOnly, until, subscription.Location())
+				if err != nil {
+					followupError(s, i, fmt.Sprintf("`%s` is not a valid date. Please use the format YYYY-MM-DD, e.g. `2026-12-20`.", until))
+					return
+				}
+				if !date.After(time.Now()) {
+					followupError(s, i, "The date to resume on must be in the future.")
+					return
+				}
+				resumeAts[j] = &date
+			}
+		}
+
+		for j, subscription := range selected {
+			err = subscription.Pause(db, resumeAts[j], skip)
+			if err != nil {
+				log.Errorf("Error pausing subscription %s for user %s: %v", subscription.ID, user_id, err)
+				followupError(s, i, "Something went wrong")
+				return
+			}
+		}
+
+		fields := []*discordgo.MessageEmbedField{}
+
+		for j := range subscriptions {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  subscriptionTitle(j+1, &subscriptions[j]),
+				Value: describeSubscription(&subscriptions[j]),
+			})
+		}
+
+		description := "Successfully paused"
+		if skip {
+			description += "\nJobs found while paused will be skipped."
+		} else {
+			description += "\nJobs found while paused will be sent when resumed."
+		}
+
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "Internly Notifications",
+					Color:       0x00ff00,
+					Description: description,
+					Fields:      fields,
+				},
+			},
+		})
+	}
+}
+
+func PauseCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
+	return Command{
+		Command: &discordgo.ApplicationCommand{
+			Name:        "pause",
+			Description: "Pause your subscriptions",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "id",
+					Description:  "Name of the subscription to pause, or leave empty to pause all of them",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "until",
+					Description: "Date to resume on automatically, e.g. 2026-12-20",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "missed",
+					Description: "What to do with jobs found while paused (defaults to sending them)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Send them when resumed",
+							Value: "send",
+						},
+						{
+							Name:  "Skip them",
+							Value: "skip",
+						},
+					},
+				},
+			},
+		},
+		Executor:     RunPauseCommand(log, db),
+		Autocomplete: RunSubscriptionAutocomplete(log, db),
+	}
+}
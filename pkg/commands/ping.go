@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxRolePings is the most role pings a guild can have, which is as many as /ping list can show in one embed.
+const maxRolePings = 25
+
+func RunPingCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+
+		data := i.ApplicationCommandData()
+		if len(data.Options) == 0 {
+			return
+		}
+		subcommand := data.Options[0]
+
+		options := commandOptions{}
+		for _, option := range subcommand.Options {
+			options[option.Name] = option
+		}
+
+		guild, err := findGuild(db, i.GuildID)
+		if err != nil {
+			log.Errorf("Error finding guild %s: %v", i.GuildID, err)
+			followupError(s, i, "Something went wrong")
+			return
+		}
+
+		switch subcommand.Name {
+		case "add":
+			addRolePing(log, db, s, i, guild, options)
+		case "list":
+			listRolePings(log, db, s, i, guild)
+		case "remove":
+			removeRolePing(log, db, s, i, guild, options)
+		}
+	}
+}
+
+// addRolePing creates the ping for a role, or replaces its filter if the role already has one.
+func addRolePing(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	roleID := options["role"].RoleValue(nil, "").ID
+	if roleID == i.GuildID {
+		followupError(s, i, "@everyone can't be pinged for jobs. Please choose a role members can take instead.")
+		return
+	}
+
+	var pings []models.RolePing
+	err := db.Where("guild_id = ?", guild.ID).Find(&pings).Error
+	if err != nil {
+		log.Errorf("Error finding role pings for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	ping := models.RolePing{GuildID: guild.ID, RoleID: roleID}
+	description := "Successfully added the ping. The role will be mentioned with the jobs posted from now on that match it."
+	replaced := false
+
+	for _, existing := range pings {
+		if existing.RoleID == roleID {
+			ping = models.RolePing{ID: existing.ID, GuildID: guild.ID, RoleID: roleID, CreatedAt: existing.CreatedAt}
+			description = "Successfully replaced the role's ping."
+			replaced = true
+		}
+	}
+
+	if !replaced && len(pings) >= maxRolePings {
+		followupError(s, i, fmt.Sprintf("This server already has the maximum of %d role pings. Please remove one before adding another.", maxRolePings))
+		return
+	}
+
+	if option, ok := options["channel"]; ok {
+		ping.ChannelID = option.ChannelValue(nil).ID
+	}
+
+	err = applyFilterOptions(&ping.Filter, options)
+	if err != nil {
+		followupError(s, i, fmt.Sprintf("Your query is invalid: %s.\n\nExample: `role:(quant OR trading) AND -company:\"Revature\"`", err))
+		return
+	}
+
+	err = db.Save(&ping).Error
+	if err != nil {
+		log.Errorf("Error saving role ping for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	followupRolePing(s, i, description, &ping)
+}
+
+func listRolePings(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild) {
+	var pings []models.RolePing
+	err := db.Where("guild_id = ?", guild.ID).Order("created_at ASC").Find(&pings).Error
+	if err != nil {
+		log.Errorf("Error finding role pings for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	description := fmt.Sprintf("Each channel gets at most %d role pings an hour, including the roles its feeds ping with every job.", models.MaxRolePingsPerHour)
+	if len(pings) == 0 {
+		description = "This server has no role pings. Use `/ping add` to create one."
+	}
+
+	fields := []*discordgo.MessageEmbedField{}
+
+	for _, ping := range pings {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Role Ping",
+			Value: describeRolePing(&ping),
+		})
+	}
+
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Internly Role Pings",
+				Color:       0x00ff00,
+				Description: description,
+				Fields:      fields,
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func removeRolePing(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, guild *models.Guild, options commandOptions) {
+	roleID := options["role"].RoleValue(nil, "").ID
+
+	var ping models.RolePing
+	err := db.Where("guild_id = ? AND role_id = ?", guild.ID, roleID).First(&ping).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			log.Errorf("Error finding role ping for guild %s: %v", guild.GuildID, err)
+		}
+		followupError(s, i, fmt.Sprintf("<@&%s> isn't pinged for any jobs. Use `/ping list` to see this server's role pings.", roleID))
+		return
+	}
+
+	err = db.Delete(&ping).Error
+	if err != nil {
+		log.Errorf("Error deleting role ping for guild %s: %v", guild.GuildID, err)
+		followupError(s, i, "Something went wrong")
+		return
+	}
+
+	followupRolePing(s, i, "Successfully removed the ping.", &ping)
+}
+
+// describeRolePing lists a role ping's role, channel and filters for an embed field.
+func describeRolePing(ping *models.RolePing) string {
+	channel := "Every feed"
+	if ping.ChannelID != "" {
+		channel = fmt.Sprintf("<#%s>", ping.ChannelID)
+	}
+	return fmt.Sprintf("Role: <@&%s>\nChannel: %s\n%s", ping.RoleID, channel, describeFilter(&ping.Filter))
+}
+
+func followupRolePing(s *discordgo.Session, i *discordgo.InteractionCreate, description string, ping *models.RolePing) {
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Internly Role Pings",
+				Color:       0x00ff00,
+				Description: description,
+				Fields: []*discordgo.MessageEmbedField{
+					{
+						Name:  "Role Ping",
+						Value: describeRolePing(ping),
+					},
+				},
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func PingCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
+	var manageRoles int64 = discordgo.PermissionManageRoles
+	return Command{
+		Command: &discordgo.ApplicationCommand{
+			Name:                     "ping",
+			Description:              "Mention roles with the posted jobs that match their filters",
+			DefaultMemberPermissions: &manageRoles,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Mention a role with the posted jobs matching a filter, replacing its current one",
+					Options: append([]*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionRole,
+							Name:        "role",
+							Description: "Role to mention, e.g. one members can give themselves",
+							Required:    true,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "channel",
+							Description:  "Only mention the role in this feed channel",
+							Required:     false,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews, discordgo.ChannelTypeGuildForum},
+						},
+					}, filterOptions(false, false)...),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List this server's role pings",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Stop mentioning a role with posted jobs",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionRole,
+							Name:        "role",
+							Description: "Role to stop mentioning",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+		GuildsOnly:   true,
+		Executor:     RunPingCommand(log, db),
+		Autocomplete: RunListAutocomplete(log, db, filterListColumns),
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RunResumeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+		var user_id string
+
+		if i.Member != nil {
+			user_id = i.Member.User.ID
+		} else {
+			user_id = i.User.ID
+		}
+
+		id := ""
+
+		for _, option := range i.ApplicationCommandData().Options {
+			if option.Name == "id" {
+				id = option.StringValue()
+			}
+		}
+
+		var subscriptions []models.Subscription
+
+		err := db.Where("user_id = ? AND deleted_at IS NULL", user_id).Order("created_at DESC").Find(&subscriptions).Error
+		if err != nil {
+			log.Errorf("Error finding subscriptions for user %s: %v", user_id, err)
+			followupError(s, i, "Something went wrong")
+			return
+		}
+
+		selected, ok := selectSubscriptions(subscriptions, id)
+		if !ok {
+			followupError(s, i, "Invalid ID")
+			return
+		}
+
+		for _, subscription := range selected {
+			if subscription.Active {
+				continue
+			}
+
+			err = subscription.Resume(db)
+			if err != nil {
+				log.Errorf("Error resuming subscription %s for user %s: %v", subscription.ID, user_id, err)
+				followupError(s, i, "Something went wrong")
+				return
+			}
+		}
+
+		fields := []*discordgo.MessageEmbedField{}
+
+		for j := range subscriptions {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  subscriptionTitle(j+1, &subscriptions[j]),
+				Value: describeSubscription(&subscriptions[j]),
+			})
+		}
+
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "Internly Notifications",
+					Color:       0x00ff00,
+					Description: "Successfully resumed",
+					Fields:      fields,
+				},
+			},
+		})
+	}
+}
+
+func ResumeCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
+	return Command{
+		Command: &discordgo.ApplicationCommand{
+			Name:        "resume",
+			Description: "Resume your paused subscriptions",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "id",
+					Description:  "Name of the subscription to resume, or leave empty to resume all of them",
+					Required:     false,
+					Autocomplete: true,
+				},
+			},
+		},
+		Executor:     RunResumeCommand(log, db),
+		Autocomplete: RunSubscriptionAutocomplete(log, db),
+	}
+}
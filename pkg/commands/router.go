@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Router dispatches each interaction to the command or handler registered for it.
+// Slash commands and autocomplete are matched by command name, while components and modals are matched by their
+// custom ID up to the first ':', leaving the rest of the ID for the handler to read.
+type Router struct {
+	log          *zap.SugaredLogger
+	commands     map[string]Command
+	autocomplete map[string]CommandExecutor
+	components   map[string]CommandExecutor
+	modals       map[string]CommandExecutor
+}
+
+// NewRouter registers the commands and their handlers. It panics if two commands share a name, or register the same
+// component or modal prefix, since one would silently never be called.
+func NewRouter(log *zap.SugaredLogger, commands []Command) *Router {
+	r := &Router{
+		log:          log,
+		commands:     make(map[string]Command),
+		autocomplete: make(map[string]CommandExecutor),
+		components:   make(map[string]CommandExecutor),
+		modals:       make(map[string]CommandExecutor),
+	}
+
+	for _, command := range commands {
+		name := command.Command.Name
+		if _, ok := r.commands[name]; ok {
+			panic(fmt.Sprintf("commands: command %q is registered twice", name))
+		}
+		r.commands[name] = command
+		if command.Autocomplete != nil {
+			r.autocomplete[name] = command.Autocomplete
+		}
+		for prefix, handler := range command.Components {
+			if _, ok := r.components[prefix]; ok {
+				panic(fmt.Sprintf("commands: component prefix %q of command %q is already registered", prefix, name))
+			}
+			r.components[prefix] = handler
+		}
+		for prefix, handler := range command.Modals {
+			if _, ok := r.modals[prefix]; ok {
+				panic(fmt.Sprintf("commands: modal prefix %q of command %q is already registered", prefix, name))
+			}
+			r.modals[prefix] = handler
+		}
+	}
+
+	return r
+}
+
+// Handle is the InteractionCreate handler. Interactions nothing is registered for are ignored.
+func (r *Router) Handle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var name string
+	var handler CommandExecutor
+
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		name = i.ApplicationCommandData().Name
+		if command, ok := r.commands[name]; ok {
+			handler = command.Execute
+		}
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		name = i.ApplicationCommandData().Name
+		handler = r.autocomplete[name]
+	case discordgo.InteractionMessageComponent:
+		name, _, _ = strings.Cut(i.MessageComponentData().CustomID, ":")
+		handler = r.components[name]
+	case discordgo.InteractionModalSubmit:
+		name, _, _ = strings.Cut(i.ModalSubmitData().CustomID, ":")
+		handler = r.modals[name]
+	}
+
+	if handler == nil {
+		r.log.Debugf("No handler for %s interaction %q", i.Type, name)
+		return
+	}
+
+	Recover(r.log, name, handler)(s, i)
+}
+
+// Recover wraps an executor so that a panic is logged, and the user told something went wrong, instead of crashing
+// the bot.
+func Recover(log *zap.SugaredLogger, name string, executor CommandExecutor) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Errorf("Recovered from panic in %q: %v\n%s", name, err, debug.Stack())
+
+				// Autocomplete can't be answered with a message, and other interactions may already have been
+				// responded to, in which case this fails harmlessly.
+				if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+					respondError(s, i, "Something went wrong")
+				}
+			}
+		}()
+		executor(s, i)
+	}
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func noopExecutor(s *discordgo.Session, i *discordgo.InteractionCreate) {}
+
+// recordingTransport answers every request to Discord with an empty success, keeping the requests' bodies.
+type recordingTransport struct {
+	bodies []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	t.bodies = append(t.bodies, body)
+
+	return &http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+// newTestSession returns a session whose requests never leave the test.
+func newTestSession(t *testing.T) (*discordgo.Session, *recordingTransport) {
+	t.Helper()
+
+	s, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &recordingTransport{}
+	s.Client = &http.Client{Transport: transport}
+
+	return s, transport
+}
+
+func TestRouterHandle(t *testing.T) {
+	called := []string{}
+	executor := func(name string) CommandExecutor {
+		return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			called = append(called, name)
+		}
+	}
+
+	r := NewRouter(zap.NewNop().Sugar(), []Command{
+		{
+			Command:      &discordgo.ApplicationCommand{Name: "subscription"},
+			Executor:     executor("command"),
+			Autocomplete: executor("autocomplete"),
+			Components:   map[string]CommandExecutor{"edit": executor("component")},
+			Modals:       map[string]CommandExecutor{"edit": executor("modal")},
+		},
+	})
+
+	interactions := []*discordgo.Interaction{
+		{Type: discordgo.InteractionApplicationCommand, Data: discordgo.ApplicationCommandInteractionData{Name: "subscription"}},
+		{Type: discordgo.InteractionApplicationCommandAutocomplete, Data: discordgo.ApplicationCommandInteractionData{Name: "subscription"}},
+		{Type: discordgo.InteractionMessageComponent, Data: discordgo.MessageComponentInteractionData{CustomID: "edit:5c1e1a2b"}},
+		{Type: discordgo.InteractionModalSubmit, Data: discordgo.ModalSubmitInteractionData{CustomID: "edit:5c1e1a2b"}},
+		// Nothing is registered for these, so they are ignored.
+		{Type: discordgo.InteractionApplicationCommand, Data: discordgo.ApplicationCommandInteractionData{Name: "unknown"}},
+		{Type: discordgo.InteractionMessageComponent, Data: discordgo.MessageComponentInteractionData{CustomID: "editor:5c1e1a2b"}},
+	}
+
+	s, _ := newTestSession(t)
+	for _, interaction := range interactions {
+		r.Handle(s, &discordgo.InteractionCreate{Interaction: interaction})
+	}
+
+	want := []string{"command", "autocomplete", "component", "modal"}
+	if !slices.Equal(called, want) {
+		t.Errorf("called %v, want %v", called, want)
+	}
+}
+
+func TestRouterRecoversFromPanics(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+
+	r := NewRouter(zap.New(core).Sugar(), []Command{
+		{
+			Command: &discordgo.ApplicationCommand{Name: "subscription"},
+			Executor: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+				var subscriptions []string
+				_ = subscriptions[0]
+			},
+			Autocomplete: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+				panic("autocomplete failed")
+			},
+		},
+	})
+
+	s, transport := newTestSession(t)
+	interaction := &discordgo.Interaction{ID: "1", Token: "token", Type: discordgo.InteractionApplicationCommand, Data: discordgo.ApplicationCommandInteractionData{Name: "subscription"}}
+	r.Handle(s, &discordgo.InteractionCreate{Interaction: interaction})
+
+	if logs.Len() != 1 || !strings.Contains(logs.All()[0].Message, `Recovered from panic in "subscription"`) {
+		t.Errorf("expected the panic to be logged, got %+v", logs.All())
+	}
+	if len(transport.bodies) != 1 {
+		t.Fatalf("expected the user to be told something went wrong, got %d responses", len(transport.bodies))
+	}
+	response := discordgo.InteractionResponse{}
+	err := json.Unmarshal([]byte(transport.bodies[0]), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Data == nil || response.Data.Flags != discordgo.MessageFlagsEphemeral || len(response.Data.Embeds) != 1 || response.Data.Embeds[0].Description != "Something went wrong" {
+		t.Errorf("expected an ephemeral error response, got %s", transport.bodies[0])
+	}
+
+	// Autocomplete can't be answered with a message, so the panic is only logged.
+	interaction = &discordgo.Interaction{ID: "2", Token: "token", Type: discordgo.InteractionApplicationCommandAutocomplete, Data: discordgo.ApplicationCommandInteractionData{Name: "subscription"}}
+	r.Handle(s, &discordgo.InteractionCreate{Interaction: interaction})
+
+	if logs.Len() != 2 || len(transport.bodies) != 1 {
+		t.Errorf("expected the autocomplete panic to be logged without responding, got %d logs and %d responses", logs.Len(), len(transport.bodies))
+	}
+}
+
+func TestNewRouterPanicsOnDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []Command
+	}{
+		{
+			name: "command",
+			commands: []Command{
+				{Command: &discordgo.ApplicationCommand{Name: "subscribe"}},
+				{Command: &discordgo.ApplicationCommand{Name: "subscribe"}},
+			},
+		},
+		{
+			name: "component",
+			commands: []Command{
+				{Command: &discordgo.ApplicationCommand{Name: "subscription"}, Components: map[string]CommandExecutor{"edit": noopExecutor}},
+				{Command: &discordgo.ApplicationCommand{Name: "feed"}, Components: map[string]CommandExecutor{"edit": noopExecutor}},
+			},
+		},
+		{
+			name: "modal",
+			commands: []Command{
+				{Command: &discordgo.ApplicationCommand{Name: "subscription"}, Modals: map[string]CommandExecutor{"edit": noopExecutor}},
+				{Command: &discordgo.ApplicationCommand{Name: "feed"}, Modals: map[string]CommandExecutor{"edit": noopExecutor}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a duplicate %s to panic", test.name)
+				}
+			}()
+			NewRouter(zap.NewNop().Sugar(), test.commands)
+		})
+	}
+
+	// A component and a modal may share a prefix, since they are routed separately.
+	NewRouter(zap.NewNop().Sugar(), []Command{
+		{
+			Command:    &discordgo.ApplicationCommand{Name: "subscription"},
+			Components: map[string]CommandExecutor{"edit": noopExecutor},
+			Modals:     map[string]CommandExecutor{"edit": noopExecutor},
+		},
+	})
+}
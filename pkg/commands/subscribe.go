@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/filter"
 	"github.com/stephensulimani/internly-bot/pkg/models"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -21,6 +24,14 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 		locations_s := ""
 		companies_s := ""
 		roles_s := ""
+		excludeLocations_s := ""
+		excludeCompanies_s := ""
+		excludeRoles_s := ""
+		query := ""
+		delivery := models.DeliveryModeInstant
+		deliveryHour := 9
+		timezone := "UTC"
+		name := ""
 
 		for _, option := range i.ApplicationCommandData().Options {
 			switch option.Name {
@@ -32,6 +43,81 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 				companies_s = option.StringValue()
 			case "roles":
 				roles_s = option.StringValue()
+			case "exclude-locations":
+				excludeLocations_s = option.StringValue()
+			case "exclude-companies":
+				excludeCompanies_s = option.StringValue()
+			case "exclude-roles":
+				excludeRoles_s = option.StringValue()
+			case "query":
+				query = strings.TrimSpace(option.StringValue())
+			case "delivery":
+				delivery = models.DeliveryMode(option.StringValue())
+			case "hour":
+				deliveryHour = int(option.IntValue())
+			case "timezone":
+				timezone = strings.TrimSpace(option.StringValue())
+			case "name":
+				name = strings.ToLower(strings.TrimSpace(option.StringValue()))
+			}
+		}
+
+		if query != "" {
+			_, err := filter.Parse(query)
+			if err != nil {
+				s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+					Embeds: []*discordgo.MessageEmbed{
+						{
+							Title:       "Internly Notifications",
+							Color:       0xff0000,
+							Description: fmt.Sprintf("Your query is invalid: %s.\n\nExample: `role:(software OR backend) AND -company:\"Revature\" AND location:(remote OR NYC)`", err),
+						},
+					},
+				})
+				return
+			}
+		}
+
+		_, err := time.LoadLocation(timezone)
+		if err != nil || timezone == "" || timezone == "Local" {
+			s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+				Embeds: []*discordgo.MessageEmbed{
+					{
+						Title:       "Internly Notifications",
+						Color:       0xff0000,
+						Description: fmt.Sprintf("`%s` is not a timezone Internly recognizes. Please use a name such as `America/New_York` or `Europe/London`.", timezone),
+					},
+				},
+			})
+			return
+		}
+
+		if name != "" {
+			var count int64
+			err = db.Model(&models.Subscription{}).Where("user_id = ? AND handle = ? AND deleted_at IS NULL", i.Member.User.ID, name).Count(&count).Error
+			if err != nil {
+				log.Errorf("Error finding subscriptions for user %s: %v", i.Member.User.ID, err)
+			}
+
+			description := ""
+			switch {
+			case !models.ValidHandle(name):
+				description = fmt.Sprintf("`%s` can't be used as a name. Names can only have letters, numbers and dashes, and can't be only numbers.", name)
+			case count > 0:
+				description = fmt.Sprintf("You already have a subscription named `%s`.", name)
+			}
+
+			if description != "" {
+				s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+					Embeds: []*discordgo.MessageEmbed{
+						{
+							Title:       "Internly Notifications",
+							Color:       0xff0000,
+							Description: description,
+						},
+					},
+				})
+				return
 			}
 		}
 
@@ -40,11 +126,21 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 		roles := strings.Split(roles_s, ",")
 
 		subscription := models.Subscription{
-			UserID:    i.Interaction.Member.User.ID,
-			JobType:   models.JobType(jobType),
-			Roles:     roles,
-			Companies: companies,
-			Locations: locations,
+			UserID: i.Interaction.Member.User.ID,
+			Filter: models.Filter{
+				JobType:          models.JobType(jobType),
+				Roles:            roles,
+				Companies:        companies,
+				Locations:        locations,
+				ExcludeRoles:     strings.Split(excludeRoles_s, ","),
+				ExcludeCompanies: strings.Split(excludeCompanies_s, ","),
+				ExcludeLocations: strings.Split(excludeLocations_s, ","),
+				Query:            query,
+			},
+			Delivery:     delivery,
+			DeliveryHour: deliveryHour,
+			Timezone:     timezone,
+			Handle:       name,
 		}
 
 		user_chan, err := s.UserChannelCreate(subscription.UserID)
@@ -84,6 +180,39 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 			})
 		}
 
+		exclusions := []struct {
+			name  string
+			terms []string
+		}{
+			{"Excluded Locations", filter.Terms(subscription.ExcludeLocations)},
+			{"Excluded Companies", filter.Terms(subscription.ExcludeCompanies)},
+			{"Excluded Roles", filter.Terms(subscription.ExcludeRoles)},
+		}
+
+		for _, exclusion := range exclusions {
+			if len(exclusion.terms) > 0 {
+				fields = append(fields, &discordgo.MessageEmbedField{
+					Name:   exclusion.name,
+					Value:  strings.Join(exclusion.terms, ", "),
+					Inline: false,
+				})
+			}
+		}
+
+		if query != "" {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:   "Query",
+				Value:  fmt.Sprintf("`%s`", query),
+				Inline: false,
+			})
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Delivery",
+			Value:  describeDelivery(&subscription),
+			Inline: false,
+		})
+
 		_, err = s.ChannelMessageSendEmbed(user_chan.ID, &discordgo.MessageEmbed{
 			Title:       "Internly Subscription",
 			Description: "You have successfully subscribed to Internly notifications",
@@ -143,7 +272,7 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 			Embeds: []*discordgo.MessageEmbed{
 				{
 					Title:       "Internly Notifications",
-					Description: "You have been successfully subscribed!\n Please check your DMs.",
+					Description: fmt.Sprintf("You have been successfully subscribed as `%s`!\n Please check your DMs.", subscription.Handle),
 					Color:       0x152949,
 				},
 			},
@@ -151,6 +280,8 @@ func RunSubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
 	}
 }
 
+var minDeliveryHour float64 = 0
+
 func SubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
 	return Command{
 		Command: &discordgo.ApplicationCommand{
@@ -173,27 +304,103 @@ func SubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
 						},
 					},
 				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "locations",
+					Description:  "Locations to subscribe to, separated with commas",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "companies",
+					Description:  "Companies to subscribe to, separated with commas",
+					Required:     false,
+					Autocomplete: true,
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "locations",
-					Description: "Locations to subscribe to, separated with commas",
+					Name:        "roles",
+					Description: "Roles to subscribe to, separated with commas",
 					Required:    false,
 				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "exclude-locations",
+					Description:  "Locations to leave out, separated with commas",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "exclude-companies",
+					Description:  "Companies to leave out, separated with commas",
+					Required:     false,
+					Autocomplete: true,
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "companies",
-					Description: "Companies to subscribe to, separated with commas",
+					Name:        "exclude-roles",
+					Description: "Roles to leave out, separated with commas (e.g. hardware, phd)",
 					Required:    false,
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "roles",
-					Description: "Roles to subscribe to, separated with commas",
+					Name:        "query",
+					Description: "Advanced search, e.g. role:(software OR backend) AND -company:\"Revature\"",
+					Required:    false,
+					MaxLength:   500,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "Name to refer to the subscription by, e.g. backend-nyc (one is made up otherwise)",
+					Required:    false,
+					MaxLength:   32,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "delivery",
+					Description: "How often to send matching jobs (defaults to instant)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Instant",
+							Value: string(models.DeliveryModeInstant),
+						},
+						{
+							Name:  "Daily Digest",
+							Value: string(models.DeliveryModeDaily),
+						},
+						{
+							Name:  "Weekly Digest (Mondays)",
+							Value: string(models.DeliveryModeWeekly),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "hour",
+					Description: "Hour of the day to send digests, from 0 to 23 (defaults to 9)",
+					Required:    false,
+					MinValue:    &minDeliveryHour,
+					MaxValue:    23,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "timezone",
+					Description: "Timezone for digests, e.g. America/New_York (defaults to UTC)",
 					Required:    false,
 				},
 			},
 		},
 		GuildsOnly: true,
 		Executor:   RunSubscribeCommand(log, db),
+		Autocomplete: RunListAutocomplete(log, db, map[string]string{
+			"companies":         "company",
+			"locations":         "location",
+			"exclude-companies": "company",
+			"exclude-locations": "location",
+		}),
 	}
 }
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/filter"
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	editSelectID = "subscription-edit-select"
+	editModalID  = "subscription-edit-modal"
+)
+
+func RunSubscriptionCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := i.ApplicationCommandData().Options
+		if len(options) == 0 || options[0].Name != "edit" {
+			return
+		}
+
+		var user_id string
+
+		if i.Member != nil {
+			user_id = i.Member.User.ID
+		} else {
+			user_id = i.User.ID
+		}
+
+		var subscriptions []models.Subscription
+
+		err := db.Where("user_id = ? AND deleted_at IS NULL", user_id).Order("created_at DESC").Find(&subscriptions).Error
+		if err != nil {
+			log.Errorf("Error finding subscriptions for user %s: %v", user_id, err)
+			respondError(s, i, "Something went wrong")
+			return
+		}
+
+		if len(subscriptions) == 0 {
+			respondError(s, i, "You don't have any subscriptions to edit. Use `/subscribe` to create one.")
+			return
+		}
+
+		menuOptions := []discordgo.SelectMenuOption{}
+
+		for j, subscription := range subscriptions {
+			menuOptions = append(menuOptions, discordgo.SelectMenuOption{
+				Label:       subscriptionTitle(j+1, &subscription),
+				Value:       subscription.ID.String(),
+				Description: Truncate(strings.ReplaceAll(describeSubscription(&subscription), "\n", " · "), 100),
+			})
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{
+					{
+						Title:       "Internly Notifications",
+						Color:       0x152949,
+						Description: "Which subscription would you like to edit?",
+					},
+				},
+				Components: []discordgo.MessageComponent{
+					discordgo.ActionsRow{
+						Components: []discordgo.MessageComponent{
+							discordgo.SelectMenu{
+								CustomID:    editSelectID,
+								Placeholder: "Choose a subscription",
+								Options:     menuOptions,
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+// RunEditSelectHandler opens the edit modal for the subscription chosen from the select menu, prefilled with its
+// current filters.
+func RunEditSelectHandler(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		values := i.MessageComponentData().Values
+		if len(values) == 0 {
+			return
+		}
+
+		subscription, ok := findOwnSubscription(log, db, s, i, values[0])
+		if !ok {
+			return
+		}
+
+		input := func(id string, label string, placeholder string, terms models.StringSlice) discordgo.MessageComponent {
+			return discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.TextInput{
+						CustomID:    id,
+						Label:       label,
+						Style:       discordgo.TextInputShort,
+						Placeholder: placeholder,
+						Value:       strings.Join(filter.Terms(terms), ", "),
+						Required:    false,
+						MaxLength:   1000,
+					},
+				},
+			}
+		}
+
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseModal,
+			Data: &discordgo.InteractionResponseData{
+				CustomID: editModalID + ":" + subscription.ID.String(),
+				Title:    "Edit Subscription",
+				Components: []discordgo.MessageComponent{
+					input("roles", "Roles", "Separated with commas, e.g. software, data", subscription.Roles),
+					input("companies", "Companies", "Separated with commas, e.g. Google, Stripe", subscription.Companies),
+					input("locations", "Locations", "Separated with commas, e.g. Remote, NYC", subscription.Locations),
+				},
+			},
+		})
+		if err != nil {
+			log.Errorf("Error opening edit modal for subscription %s: %v", subscription.ID, err)
+		}
+	}
+}
+
+// RunEditModalHandler saves the filters submitted through the edit modal, keeping the subscription's delivery history.
+func RunEditModalHandler(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		data := i.ModalSubmitData()
+
+		_, id, _ := strings.Cut(data.CustomID, ":")
+
+		subscription, ok := findOwnSubscription(log, db, s, i, id)
+		if !ok {
+			return
+		}
+
+		for _, component := range data.Components {
+			row, ok := component.(*discordgo.ActionsRow)
+			if !ok {
+				continue
+			}
+			for _, rowComponent := range row.Components {
+				input, ok := rowComponent.(*discordgo.TextInput)
+				if !ok {
+					continue
+				}
+				switch input.CustomID {
+				case "roles":
+					subscription.Roles = strings.Split(input.Value, ",")
+				case "companies":
+					subscription.Companies = strings.Split(input.Value, ",")
+				case "locations":
+					subscription.Locations = strings.Split(input.Value, ",")
+				}
+			}
+		}
+
+		err := db.Model(subscription).Select("Roles", "Companies", "Locations").Updates(subscription).Error
+		if err != nil {
+			log.Errorf("Error saving subscription %s: %v", subscription.ID, err)
+			respondError(s, i, "Something went wrong")
+			return
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{
+					{
+						Title:       "Internly Notifications",
+						Color:       0x00ff00,
+						Description: "Successfully updated your subscription",
+						Fields: []*discordgo.MessageEmbedField{
+							{
+								Name:  "Subscription",
+								Value: describeSubscription(subscription),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+// findOwnSubscription finds the subscription with the given ID, responding with an error unless it belongs to the
+// user who interacted.
+func findOwnSubscription(log *zap.SugaredLogger, db *gorm.DB, s *discordgo.Session, i *discordgo.InteractionCreate, id string) (*models.Subscription, bool) {
+	var user_id string
+
+	if i.Member != nil {
+		user_id = i.Member.User.ID
+	} else {
+		user_id = i.User.ID
+	}
+
+	var subscription models.Subscription
+
+	err := db.Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, user_id).First(&subscription).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			log.Errorf("Error finding subscription %s for user %s: %v", id, user_id, err)
+		}
+		respondError(s, i, "That subscription no longer exists.")
+		return nil, false
+	}
+
+	return &subscription, true
+}
+
+// respondError responds to an interaction with an error embed only the user can see.
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, description string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "Internly Notifications",
+					Color:       0xff0000,
+					Description: description,
+				},
+			},
+		},
+	})
+}
+
+// Truncate shortens s to at most n characters, marking that it was cut with an ellipsis.
+func Truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func SubscriptionCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
+	return Command{
+		Command: &discordgo.ApplicationCommand{
+			Name:        "subscription",
+			Description: "Manage one of your subscriptions",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "edit",
+					Description: "Edit the roles, companies and locations of a subscription",
+				},
+			},
+		},
+		Executor: RunSubscriptionCommand(log, db),
+		Components: map[string]CommandExecutor{
+			editSelectID: RunEditSelectHandler(log, db),
+		},
+		Modals: map[string]CommandExecutor{
+			editModalID: RunEditModalHandler(log, db),
+		},
+	}
+}
@@ -2,8 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/stephensulimani/internly-bot/pkg/filter"
 	"github.com/stephensulimani/internly-bot/pkg/models"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -46,9 +49,8 @@ func RunSubscriptionsCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecuto
 
 		for j, subscription := range subscriptions {
 			fields = append(fields, &discordgo.MessageEmbedField{
-				Name: fmt.Sprintf("Subscription %d", j+1),
-				Value: fmt.Sprintf("Job Type: %s\nRoles: %s\nCompanies: %s\nLocations: %s",
-					subscription.JobType, subscription.Roles.String(), subscription.Companies.String(), subscription.Locations.String()),
+				Name:  subscriptionTitle(j+1, &subscription),
+				Value: describeSubscription(&subscription),
 			})
 		}
 
@@ -65,6 +67,164 @@ func RunSubscriptionsCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecuto
 	}
 }
 
+// selectSubscriptions returns the subscription with the given handle, or every subscription if id is empty.
+// The position of a subscription in /subscriptions is accepted as well, for those used to numbering them.
+// The second return value is false if there is no such subscription.
+func selectSubscriptions(subscriptions []models.Subscription, id string) ([]*models.Subscription, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+
+	if id == "" {
+		selected := make([]*models.Subscription, len(subscriptions))
+		for j := range subscriptions {
+			selected[j] = &subscriptions[j]
+		}
+		return selected, true
+	}
+
+	for j := range subscriptions {
+		if subscriptions[j].Handle == id {
+			return []*models.Subscription{&subscriptions[j]}, true
+		}
+	}
+
+	position, err := strconv.Atoi(id)
+	if err != nil || position < 1 || position > len(subscriptions) {
+		return nil, false
+	}
+
+	return []*models.Subscription{&subscriptions[position-1]}, true
+}
+
+// subscriptionTitle names a subscription by its position in /subscriptions and its handle.
+func subscriptionTitle(position int, subscription *models.Subscription) string {
+	return fmt.Sprintf("Subscription %d (%s)", position, subscription.Handle)
+}
+
+// RunSubscriptionAutocomplete suggests the handles of the user's subscriptions, for options naming one of them.
+func RunSubscriptionAutocomplete(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		var user_id string
+
+		if i.Member != nil {
+			user_id = i.Member.User.ID
+		} else {
+			user_id = i.User.ID
+		}
+
+		typed := ""
+		for _, option := range i.ApplicationCommandData().Options {
+			if option.Focused {
+				typed = strings.ToLower(strings.TrimSpace(option.StringValue()))
+			}
+		}
+
+		var subscriptions []models.Subscription
+
+		err := db.Where("user_id = ? AND deleted_at IS NULL", user_id).Order("created_at DESC").Find(&subscriptions).Error
+		if err != nil {
+			log.Errorf("Error finding subscriptions for user %s: %v", user_id, err)
+		}
+
+		choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+		for j, subscription := range subscriptions {
+			description := strings.ReplaceAll(describeSubscription(&subscription), "\n", " · ")
+			if typed != "" && !strings.Contains(subscription.Handle, typed) && !strings.Contains(strings.ToLower(description), typed) {
+				continue
+			}
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  Truncate(fmt.Sprintf("%s: %s", subscriptionTitle(j+1, &subscription), description), maxChoiceLength),
+				Value: subscription.Handle,
+			})
+		}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{
+				Choices: choices,
+			},
+		})
+		if err != nil {
+			log.Errorf("Error responding to autocomplete: %v", err)
+		}
+	}
+}
+
+// followupError responds to a deferred interaction with an error embed only the user can see.
+func followupError(s *discordgo.Session, i *discordgo.InteractionCreate, description string) {
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Internly Notifications",
+				Color:       0xff0000,
+				Description: description,
+			},
+		},
+	})
+}
+
+// describeSubscription lists a subscription's filters for an embed field.
+func describeSubscription(subscription *models.Subscription) string {
+	description := describeFilter(&subscription.Filter)
+
+	description += fmt.Sprintf("\nDelivery: %s", describeDelivery(subscription))
+
+	if !subscription.Active {
+		description += "\nStatus: Paused"
+		if subscription.PausedUntil != nil {
+			description += fmt.Sprintf(" until <t:%d:D>", subscription.PausedUntil.Unix())
+		}
+		if subscription.SkipWhilePaused {
+			description += ", skipping missed jobs"
+		}
+	}
+
+	return description
+}
+
+// describeFilter lists the fields of a filter, leaving out the exclusions and query if they aren't set.
+func describeFilter(f *models.Filter) string {
+	jobType := string(f.JobType)
+	if jobType == "" {
+		jobType = "Any"
+	}
+
+	description := fmt.Sprintf("Job Type: %s\nRoles: %s\nCompanies: %s\nLocations: %s",
+		jobType, f.Roles.String(), f.Companies.String(), f.Locations.String())
+
+	exclusions := []struct {
+		name  string
+		terms []string
+	}{
+		{"Excluded Roles", filter.Terms(f.ExcludeRoles)},
+		{"Excluded Companies", filter.Terms(f.ExcludeCompanies)},
+		{"Excluded Locations", filter.Terms(f.ExcludeLocations)},
+	}
+
+	for _, exclusion := range exclusions {
+		if len(exclusion.terms) > 0 {
+			description += fmt.Sprintf("\n%s: %s", exclusion.name, strings.Join(exclusion.terms, ","))
+		}
+	}
+
+	if f.Query != "" {
+		description += fmt.Sprintf("\nQuery: `%s`", f.Query)
+	}
+
+	return description
+}
+
+// describeDelivery says how often a subscription's jobs are sent, such as "Daily at 09:00 (America/New_York)".
+func describeDelivery(subscription *models.Subscription) string {
+	switch subscription.Delivery {
+	case models.DeliveryModeDaily:
+		return fmt.Sprintf("Daily at %02d:00 (%s)", subscription.DeliveryHour, subscription.Location())
+	case models.DeliveryModeWeekly:
+		return fmt.Sprintf("Weekly on Mondays at %02d:00 (%s)", subscription.DeliveryHour, subscription.Location())
+	}
+	return "Instant"
+}
+
 func SubscriptionsCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
 	return Command{
 		Command: &discordgo.ApplicationCommand{
@@ -1,7 +1,8 @@
 package commands
 
 import (
-	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/stephensulimani/internly-bot/pkg/models"
@@ -42,9 +43,11 @@ func RunUnsubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor
 			})
 		}
 
-		id := i.ApplicationCommandData().Options[0].IntValue() - 1
+		id := i.ApplicationCommandData().Options[0].StringValue()
 
-		if id < 0 || int(id) >= len(subscriptions) {
+		selected, ok := selectSubscriptions(subscriptions, id)
+
+		if strings.TrimSpace(id) == "" || !ok {
 			s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 				Embeds: []*discordgo.MessageEmbed{
 					{
@@ -57,7 +60,7 @@ func RunUnsubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor
 			return
 		}
 
-		subscription := subscriptions[id]
+		subscription := *selected[0]
 
 		err = db.Delete(&subscription).Error
 		if err != nil {
@@ -76,13 +79,14 @@ func RunUnsubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) CommandExecutor
 
 		fields := []*discordgo.MessageEmbedField{}
 
-		subscriptions = append(subscriptions[:id], subscriptions[id+1:]...)
+		subscriptions = slices.DeleteFunc(subscriptions, func(sub models.Subscription) bool {
+			return sub.ID == subscription.ID
+		})
 
 		for j, sub := range subscriptions {
 			fields = append(fields, &discordgo.MessageEmbedField{
-				Name: fmt.Sprintf("Subscription %d", j+1),
-				Value: fmt.Sprintf("Job Type: %s\nRoles: %s\nCompanies: %s\nLocations: %s",
-					sub.JobType, sub.Roles.String(), sub.Companies.String(), sub.Locations.String()),
+				Name:  subscriptionTitle(j+1, &sub),
+				Value: describeSubscription(&sub),
 			})
 		}
 
@@ -106,13 +110,15 @@ func UnsubscribeCommand(log *zap.SugaredLogger, db *gorm.DB) Command {
 			Description: "Unsubscribe from a position",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Type:        discordgo.ApplicationCommandOptionInteger,
-					Name:        "id",
-					Description: "ID of the position to unsubscribe from",
-					Required:    true,
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "id",
+					Description:  "Name of the subscription to unsubscribe from, as shown in /subscriptions",
+					Required:     true,
+					Autocomplete: true,
 				},
 			},
 		},
-		Executor: RunUnsubscribeCommand(log, db),
+		Executor:     RunUnsubscribeCommand(log, db),
+		Autocomplete: RunSubscriptionAutocomplete(log, db),
 	}
 }
@@ -2,16 +2,25 @@ package pkg
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
 )
 
 type Config struct {
 	DatabaseName string `json:"dbName"`
 	BotToken     string `json:"discordToken"`
 	PollTime_d   time.Duration
-	PollTime     string `json:"pollTime"`
+	PollTime     string               `json:"pollTime"`
+	Greenhouse   []models.Board       `json:"greenhouse"`
+	Lever        []models.Board       `json:"lever"`
+	Ashby        []models.Board       `json:"ashby"`
+	Workday      []models.WorkdaySite `json:"workday"`
+	Sites        []models.Site        `json:"sites"`
+	RSS          []models.RSSFeed     `json:"rss"`
 }
 
 func (c *Config) Validate() error {
@@ -46,5 +55,49 @@ func (c *Config) Validate() error {
 
 	}
 
+	err := validateBoards("greenhouse", c.Greenhouse)
+	if err != nil {
+		return err
+	}
+
+	err = validateBoards("lever", c.Lever)
+	if err != nil {
+		return err
+	}
+
+	err = validateBoards("ashby", c.Ashby)
+	if err != nil {
+		return err
+	}
+
+	for i, site := range c.Workday {
+		if site.Host == "" || site.Tenant == "" || site.Site == "" {
+			return fmt.Errorf("workday site %d must have a host, tenant and site", i+1)
+		}
+	}
+
+	for i := range c.Sites {
+		err = c.Sites[i].Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range c.RSS {
+		err = c.RSS[i].Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBoards(source string, boards []models.Board) error {
+	for i, board := range boards {
+		if board.Token == "" {
+			return fmt.Errorf("%s board %d is missing a token", source, i+1)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,128 @@
+// Package filter matches jobs against a subscription's or feed's filters, either as a parameterized
+// database query or in memory against a single job.
+package filter
+
+import (
+	"strings"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"gorm.io/gorm"
+)
+
+// likeEscaper escapes the LIKE wildcards so that user input is always matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Terms returns the non-empty, trimmed values of a filter field.
+// StringSlice.Scan turns an empty column into [""] and "a,b," into ["a", "b", ""], so those empty values are dropped.
+func Terms(values models.StringSlice) []string {
+	terms := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			terms = append(terms, value)
+		}
+	}
+	return terms
+}
+
+// Query narrows a query over the jobs table to the jobs matching the filter.
+// Values within a field are ORed together and the fields are ANDed, with every value bound as a parameter.
+// Jobs matching any excluded value are left out, and the filter's query must match as well.
+// A filter without a job type matches every type.
+func Query(db *gorm.DB, f *models.Filter) *gorm.DB {
+	if f.JobType != "" {
+		db = db.Where("jobs.job_type = ?", f.JobType)
+	}
+
+	db = whereAnyLike(db, "jobs.location", Terms(f.Locations))
+	db = whereAnyLike(db, "jobs.company", Terms(f.Companies))
+	db = whereAnyLike(db, "jobs.role", Terms(f.Roles))
+	db = whereNoneLike(db, "jobs.location", Terms(f.ExcludeLocations))
+	db = whereNoneLike(db, "jobs.company", Terms(f.ExcludeCompanies))
+	db = whereNoneLike(db, "jobs.role", Terms(f.ExcludeRoles))
+
+	if f.Query != "" {
+		node, err := Parse(f.Query)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		condition, args := node.SQL()
+		db = db.Where(condition, args...)
+	}
+
+	return db
+}
+
+// Match reports whether a job matches the filter, mirroring Query.
+func Match(f *models.Filter, job *models.Job) bool {
+	if f.JobType != "" && job.JobType != f.JobType {
+		return false
+	}
+
+	if f.Query != "" {
+		node, err := Parse(f.Query)
+		if err != nil || !node.Match(job) {
+			return false
+		}
+	}
+
+	return containsAny(job.Location, Terms(f.Locations)) &&
+		containsAny(job.Company, Terms(f.Companies)) &&
+		containsAny(job.Role, Terms(f.Roles)) &&
+		containsNone(job.Location, Terms(f.ExcludeLocations)) &&
+		containsNone(job.Company, Terms(f.ExcludeCompanies)) &&
+		containsNone(job.Role, Terms(f.ExcludeRoles))
+}
+
+func whereAnyLike(db *gorm.DB, column string, terms []string) *gorm.DB {
+	if len(terms) == 0 {
+		return db
+	}
+
+	condition, args := anyLike(column, terms)
+	return db.Where(condition, args...)
+}
+
+func whereNoneLike(db *gorm.DB, column string, terms []string) *gorm.DB {
+	if len(terms) == 0 {
+		return db
+	}
+
+	// NOT on a NULL column is NULL, so jobs missing the field would otherwise be excluded too.
+	condition, args := anyLike(column, terms)
+	return db.Where("("+column+" IS NULL OR NOT "+condition+")", args...)
+}
+
+// anyLike builds a parenthesized condition matching the column against any of the terms.
+func anyLike(column string, terms []string) (string, []any) {
+	conditions := make([]string, len(terms))
+	args := make([]any, len(terms))
+	for i, term := range terms {
+		conditions[i] = column + ` LIKE ? ESCAPE '\'`
+		args[i] = "%" + likeEscaper.Replace(term) + "%"
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// containsAny reports whether value contains any of the terms, ignoring case like SQLite's LIKE.
+// A field without any terms matches everything.
+func containsAny(value string, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+
+	value = strings.ToLower(value)
+	for _, term := range terms {
+		if strings.Contains(value, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsNone reports whether value contains none of the terms, ignoring case.
+func containsNone(value string, terms []string) bool {
+	return len(terms) == 0 || !containsAny(value, terms)
+}
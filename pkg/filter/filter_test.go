@@ -0,0 +1,249 @@
+package filter
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var testJobs = []models.Job{
+	{Company: "Macy's", Role: "Software Engineer Intern", Location: "New York, NY", JobType: models.INTERN},
+	{Company: "100% Remote Co", Role: "Backend Intern", Location: "Remote", JobType: models.INTERN},
+	{Company: "snake_case Labs", Role: "Data Science Intern", Location: "Austin, TX", JobType: models.INTERN},
+	{Company: "snakeXcase Labs", Role: "Data Analyst Intern", Location: "Austin, TX", JobType: models.INTERN},
+	{Company: "Revature", Role: "Software Engineer", Location: "Remote", JobType: models.NEW_GRAD},
+	{Company: "Jane Street", Role: "Quantitative Trader", Location: "New York, NY", JobType: models.NEW_GRAD},
+	{Company: "Acme", Role: "Hardware Intern", Location: "", JobType: models.INTERN},
+}
+
+// newTestDB saves testJobs to a database that only lasts for the test.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.Migrate(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, job := range testJobs {
+		job.ApplicationLink = "https://example.com/" + job.Company + "/" + job.Role
+		job.FirstSeen = time.Now()
+		err = db.Create(&job).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		testJobs[i].ID = job.ID
+	}
+
+	return db
+}
+
+// scanned returns the StringSlice read back from a column holding value.
+func scanned(t *testing.T, value string) models.StringSlice {
+	t.Helper()
+
+	var s models.StringSlice
+	err := s.Scan(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		values models.StringSlice
+		want   []string
+	}{
+		{nil, []string{}},
+		{scanned(t, ""), []string{}},
+		{scanned(t, "Google,"), []string{"Google"}},
+		{scanned(t, " Google , ,Meta"), []string{"Google", "Meta"}},
+	}
+
+	for _, test := range tests {
+		got := Terms(test.values)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("Terms(%q) = %q, want %q", test.values, got, test.want)
+		}
+	}
+}
+
+// TestQueryMatchesMatch runs each filter both as a database query and in memory, which must find the same jobs.
+func TestQueryMatchesMatch(t *testing.T) {
+	db := newTestDB(t)
+
+	tests := []struct {
+		name   string
+		filter models.Filter
+		want   []string
+	}{
+		{
+			name:   "job type only",
+			filter: models.Filter{JobType: models.NEW_GRAD},
+			want:   []string{"Revature", "Jane Street"},
+		},
+		{
+			name:   "any job type",
+			filter: models.Filter{Locations: models.StringSlice{"remote"}},
+			want:   []string{"100% Remote Co", "Revature"},
+		},
+		{
+			name:   "apostrophe",
+			filter: models.Filter{JobType: models.INTERN, Companies: models.StringSlice{"Macy's"}},
+			want:   []string{"Macy's"},
+		},
+		{
+			name:   "percent is literal",
+			filter: models.Filter{JobType: models.INTERN, Companies: models.StringSlice{"100%"}},
+			want:   []string{"100% Remote Co"},
+		},
+		{
+			name:   "underscore is literal",
+			filter: models.Filter{JobType: models.INTERN, Companies: models.StringSlice{"snake_case"}},
+			want:   []string{"snake_case Labs"},
+		},
+		{
+			name:   "injection is searched for",
+			filter: models.Filter{JobType: models.INTERN, Companies: models.StringSlice{"' OR '1'='1"}},
+			want:   []string{},
+		},
+		{
+			name:   "empty fields match everything",
+			filter: models.Filter{JobType: models.INTERN, Roles: scanned(t, ""), Companies: scanned(t, ""), Locations: scanned(t, "")},
+			want:   []string{"Macy's", "100% Remote Co", "snake_case Labs", "snakeXcase Labs", "Acme"},
+		},
+		{
+			name:   "trailing empty value",
+			filter: models.Filter{JobType: models.INTERN, Companies: scanned(t, "Macy's,")},
+			want:   []string{"Macy's"},
+		},
+		{
+			name:   "values are ORed and fields ANDed",
+			filter: models.Filter{JobType: models.INTERN, Roles: scanned(t, "data,backend"), Locations: scanned(t, "austin")},
+			want:   []string{"snake_case Labs", "snakeXcase Labs"},
+		},
+		{
+			name:   "exclusions keep jobs missing the field",
+			filter: models.Filter{JobType: models.INTERN, ExcludeLocations: scanned(t, "new york,remote,")},
+			want:   []string{"snake_case Labs", "snakeXcase Labs", "Acme"},
+		},
+		{
+			name:   "excluded company",
+			filter: models.Filter{JobType: models.NEW_GRAD, ExcludeCompanies: models.StringSlice{"revature"}},
+			want:   []string{"Jane Street"},
+		},
+		{
+			name:   "query",
+			filter: models.Filter{Query: `role:(software OR quant) AND -company:"Revature"`},
+			want:   []string{"Macy's", "Jane Street"},
+		},
+		{
+			name:   "query with quotes and fields",
+			filter: models.Filter{Query: `company:"Macy's" OR location:"new york"`},
+			want:   []string{"Macy's", "Jane Street"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var jobs []models.Job
+			err := Query(db.Model(&models.Job{}), &test.filter).Find(&jobs).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Jobs are compared in the order of testJobs, since rows come back in no particular order.
+			queried := []string{}
+			for _, testJob := range testJobs {
+				if slices.ContainsFunc(jobs, func(job models.Job) bool { return job.ID == testJob.ID }) {
+					queried = append(queried, testJob.Company)
+				}
+			}
+
+			matched := []string{}
+			for _, job := range testJobs {
+				if Match(&test.filter, &job) {
+					matched = append(matched, job.Company)
+				}
+			}
+
+			if !slices.Equal(queried, test.want) {
+				t.Errorf("Query found %q, want %q", queried, test.want)
+			}
+			if !slices.Equal(matched, test.want) {
+				t.Errorf("Match found %q, want %q", matched, test.want)
+			}
+		})
+	}
+}
+
+func TestQueryInvalid(t *testing.T) {
+	db := newTestDB(t)
+
+	f := models.Filter{JobType: models.INTERN, Query: "role:(software"}
+
+	var jobs []models.Job
+	err := Query(db.Model(&models.Job{}), &f).Find(&jobs).Error
+	if err == nil {
+		t.Error("expected an invalid query to return an error")
+	}
+	if Match(&f, &testJobs[0]) {
+		t.Error("expected an invalid query to match nothing")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"unclosed parenthesis", "(software", "this `(` is never closed (at character 1)"},
+		{"unclosed field group", "role:(software OR backend", "this `(` is never closed (at character 6)"},
+		{"unopened parenthesis", "software)", "there is a `)` without a matching `(` (at character 9)"},
+		{"dangling AND", "software AND", "the query ends where a search term was expected (at character 13)"},
+		{"dangling OR", "software OR", "the query ends where a search term was expected (at character 12)"},
+		{"dangling NOT", "NOT", "the query ends where a search term was expected (at character 4)"},
+		{"leading operator", "OR software", "expected a search term, but found `OR` (at character 1)"},
+		{"doubled operator", "software AND OR backend", "expected a search term, but found `OR` (at character 14)"},
+		{"empty group", "()", "expected a search term before `)` (at character 2)"},
+		{"empty field group", "role:()", "expected a search term before `)` (at character 7)"},
+		{"unterminated quote", `"software`, "a quote is never closed (at character 1)"},
+		{"unterminated field quote", `role:"new grad`, "a quote is never closed (at character 6)"},
+		{"empty quotes", `""`, "empty quotes can not be searched for (at character 1)"},
+		{"empty query", "   ", "the query is empty (at character 1)"},
+		{"unknown field", "team:software", "unknown field `team`, expected role, company or location (at character 1)"},
+		{"field without a value", "role:", "expected a value after `role:` (at character 5)"},
+		{"nested field", "role:(company:google)", "`company:` can not be used inside another field's parentheses (at character 7)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.query)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) returned %v, want a ParseError", test.query, err)
+			}
+			if err.Error() != test.want {
+				t.Errorf("Parse(%q) error = %q, want %q", test.query, err.Error(), test.want)
+			}
+		})
+	}
+}
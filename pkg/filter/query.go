@@ -0,0 +1,378 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+const (
+	maxQueryLength = 500
+	maxQueryTerms  = 50
+)
+
+// Field is the job field a query term is matched against. An empty Field matches the role, company or location.
+type Field string
+
+const (
+	FieldAny      Field = ""
+	FieldRole     Field = "role"
+	FieldCompany  Field = "company"
+	FieldLocation Field = "location"
+)
+
+var fieldColumns = map[Field]string{
+	FieldRole:     "jobs.role",
+	FieldCompany:  "jobs.company",
+	FieldLocation: "jobs.location",
+}
+
+// Node is a parsed subscription query, such as `role:(software OR backend) AND -company:"Revature"`.
+type Node interface {
+	// SQL returns a condition over the jobs table with every value bound as a parameter.
+	SQL() (string, []any)
+	// Match evaluates the query against a single job, equivalently to SQL.
+	Match(job *models.Job) bool
+}
+
+type termNode struct {
+	field Field
+	value string
+}
+
+type notNode struct {
+	node Node
+}
+
+type binaryNode struct {
+	op          models.QueryOperator
+	left, right Node
+}
+
+func (t *termNode) SQL() (string, []any) {
+	columns := []string{fieldColumns[t.field]}
+	if t.field == FieldAny {
+		columns = []string{fieldColumns[FieldRole], fieldColumns[FieldCompany], fieldColumns[FieldLocation]}
+	}
+
+	conditions := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		// COALESCE keeps NOT from turning a missing field into NULL, which would exclude the job.
+		conditions[i] = "COALESCE(" + column + `, '') LIKE ? ESCAPE '\'`
+		args[i] = "%" + likeEscaper.Replace(t.value) + "%"
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+func (t *termNode) Match(job *models.Job) bool {
+	switch t.field {
+	case FieldRole:
+		return containsAny(job.Role, []string{t.value})
+	case FieldCompany:
+		return containsAny(job.Company, []string{t.value})
+	case FieldLocation:
+		return containsAny(job.Location, []string{t.value})
+	}
+	return containsAny(job.Role, []string{t.value}) ||
+		containsAny(job.Company, []string{t.value}) ||
+		containsAny(job.Location, []string{t.value})
+}
+
+func (n *notNode) SQL() (string, []any) {
+	condition, args := n.node.SQL()
+	return "(NOT " + condition + ")", args
+}
+
+func (n *notNode) Match(job *models.Job) bool {
+	return !n.node.Match(job)
+}
+
+func (b *binaryNode) SQL() (string, []any) {
+	left, leftArgs := b.left.SQL()
+	right, rightArgs := b.right.SQL()
+	return "(" + left + " " + string(b.op) + " " + right + ")", append(leftArgs, rightArgs...)
+}
+
+func (b *binaryNode) Match(job *models.Job) bool {
+	if b.op == models.QueryOperatorOr {
+		return b.left.Match(job) || b.right.Match(job)
+	}
+	return b.left.Match(job) && b.right.Match(job)
+}
+
+// ParseError describes why a query is invalid in a way that can be shown to the user.
+type ParseError struct {
+	Position int
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s (at character %d)", e.Message, e.Position+1)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenQuoted
+	tokenLeftParen
+	tokenRightParen
+	tokenColon
+	tokenMinus
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// Parse parses a subscription query.
+// Terms are ANDed together unless separated by OR, and may be negated with a leading - or NOT.
+// A term may be limited to a field with role:, company: or location:, which also applies to a parenthesized group.
+// Values containing spaces must be quoted.
+func Parse(query string) (Node, error) {
+	if len(query) > maxQueryLength {
+		return nil, &ParseError{Position: maxQueryLength, Message: fmt.Sprintf("queries can be at most %d characters long", maxQueryLength)}
+	}
+
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	if p.peek().kind == tokenEOF {
+		return nil, &ParseError{Position: 0, Message: "the query is empty"}
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		if t.kind == tokenRightParen {
+			return nil, &ParseError{Position: t.pos, Message: "there is a `)` without a matching `(`"}
+		}
+		return nil, &ParseError{Position: t.pos, Message: fmt.Sprintf("unexpected `%s`", t.value)}
+	}
+
+	return node, nil
+}
+
+func tokenize(query string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", pos: i})
+			i++
+		case r == ':':
+			tokens = append(tokens, token{kind: tokenColon, value: ":", pos: i})
+			i++
+		case r == '-':
+			tokens = append(tokens, token{kind: tokenMinus, value: "-", pos: i})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, &ParseError{Position: i, Message: "a quote is never closed"}
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, value: string(runes[i+1 : end]), pos: i})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`():"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			kind := tokenWord
+			switch word {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, token{kind: kind, value: word, pos: i})
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	terms  int
+	// field is set while parsing a group such as role:(a OR b), and applies to every term within it.
+	field  Field
+	scoped bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: models.QueryOperatorOr, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenWord, tokenQuoted, tokenLeftParen, tokenMinus, tokenNot:
+			// Terms next to each other are implicitly ANDed.
+		default:
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: models.QueryOperatorAnd, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if kind := p.peek().kind; kind == tokenMinus || kind == tokenNot {
+		p.next()
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: node}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenLeftParen:
+		return p.parseGroup(t)
+	case tokenQuoted:
+		return p.term(p.field, t)
+	case tokenWord:
+		if p.peek().kind != tokenColon {
+			return p.term(p.field, t)
+		}
+		return p.parseField(t)
+	case tokenEOF:
+		return nil, &ParseError{Position: t.pos, Message: "the query ends where a search term was expected"}
+	case tokenRightParen:
+		return nil, &ParseError{Position: t.pos, Message: "expected a search term before `)`"}
+	default:
+		return nil, &ParseError{Position: t.pos, Message: fmt.Sprintf("expected a search term, but found `%s`", t.value)}
+	}
+}
+
+func (p *parser) parseGroup(open token) (Node, error) {
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.next().kind != tokenRightParen {
+		return nil, &ParseError{Position: open.pos, Message: "this `(` is never closed"}
+	}
+
+	return node, nil
+}
+
+func (p *parser) parseField(name token) (Node, error) {
+	colon := p.next()
+
+	if p.scoped {
+		return nil, &ParseError{Position: name.pos, Message: fmt.Sprintf("`%s:` can not be used inside another field's parentheses", name.value)}
+	}
+
+	field := Field(strings.ToLower(name.value))
+	if _, ok := fieldColumns[field]; !ok {
+		return nil, &ParseError{Position: name.pos, Message: fmt.Sprintf("unknown field `%s`, expected role, company or location", name.value)}
+	}
+
+	value := p.next()
+
+	switch value.kind {
+	case tokenWord, tokenQuoted:
+		return p.term(field, value)
+	case tokenLeftParen:
+		p.field, p.scoped = field, true
+		node, err := p.parseGroup(value)
+		p.field, p.scoped = FieldAny, false
+		return node, err
+	}
+
+	return nil, &ParseError{Position: colon.pos, Message: fmt.Sprintf("expected a value after `%s:`", name.value)}
+}
+
+func (p *parser) term(field Field, t token) (Node, error) {
+	value := strings.TrimSpace(t.value)
+	if value == "" {
+		return nil, &ParseError{Position: t.pos, Message: "empty quotes can not be searched for"}
+	}
+
+	p.terms++
+	if p.terms > maxQueryTerms {
+		return nil, &ParseError{Position: t.pos, Message: fmt.Sprintf("queries can have at most %d search terms", maxQueryTerms)}
+	}
+
+	return &termNode{field: field, value: value}, nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Feed posts the jobs matching its filter to one of a guild's channels.
+type Feed struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GuildID uuid.UUID `gorm:"not null;uniqueIndex:idx_guild_feed_name" json:"guildId"`
+	// Name identifies the feed in /feed commands, and is unique within its guild.
+	Name      string `gorm:"uniqueIndex:idx_guild_feed_name" json:"name"`
+	ChannelID string `json:"channelId"`
+	Filter    `gorm:"embedded"`
+	// RoleID is a role mentioned with every job posted, or empty to not mention anyone.
+	RoleID string `json:"roleId"`
+
+	Guild Guild `gorm:"foreignKey:GuildID"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (f *Feed) BeforeCreate(tx *gorm.DB) (err error) {
+	f.ID = uuid.New()
+	return
+}
+
+// Default feed names, which /configure manages and guilds' original intern and new grad channels became.
+const (
+	FeedNameInterns  = "interns"
+	FeedNameNewGrads = "new-grads"
+)
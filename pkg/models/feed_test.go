@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestFeedNamesUniquePerGuild(t *testing.T) {
+	db := newTestDB(t)
+
+	guildID := uuid.New()
+	err := db.Create(&Feed{GuildID: guildID, Name: "quant"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Create(&Feed{GuildID: guildID, Name: "quant"}).Error
+	if err != gorm.ErrDuplicatedKey {
+		t.Fatalf("creating a feed with a taken name returned %v, want %v", err, gorm.ErrDuplicatedKey)
+	}
+
+	err = db.Create(&Feed{GuildID: uuid.New(), Name: "quant"}).Error
+	if err != nil {
+		t.Fatalf("creating a feed with a name taken in another guild returned %v", err)
+	}
+}
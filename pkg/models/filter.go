@@ -0,0 +1,15 @@
+package models
+
+// Filter is what a job must match to be sent to a subscriber or posted to a feed.
+// Values within a field are ORed together, while the fields are ANDed. The filter package matches jobs against it.
+type Filter struct {
+	JobType          JobType     `json:"jobType"`
+	Roles            StringSlice `json:"roles" gorm:"type:text"`
+	Companies        StringSlice `json:"companies" gorm:"type:text"`
+	Locations        StringSlice `json:"locations" gorm:"type:text"`
+	ExcludeRoles     StringSlice `json:"excludeRoles" gorm:"type:text"`
+	ExcludeCompanies StringSlice `json:"excludeCompanies" gorm:"type:text"`
+	ExcludeLocations StringSlice `json:"excludeLocations" gorm:"type:text"`
+	// Query is an optional boolean search, parsed by the filter package, that jobs must also match.
+	Query string `json:"query"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import "regexp"
+
+// Discord allows a forum 20 tags, and a thread 5 of them.
+const maxAppliedTags = 5
+
+// Forum tags describing a job, applied to the thread it gets in a forum feed.
+const (
+	ForumTagIntern   = "Internship"
+	ForumTagNewGrad  = "New Grad"
+	ForumTagRemote   = "Remote"
+	ForumTagUS       = "United States"
+	ForumTagCanada   = "Canada"
+	ForumTagEurope   = "Europe"
+	ForumTagAsia     = "Asia"
+	ForumTagSoftware = "Software"
+	ForumTagData     = "Data & ML"
+	ForumTagQuant    = "Quant"
+	ForumTagHardware = "Hardware"
+	ForumTagProduct  = "Product"
+	ForumTagDesign   = "Design"
+	ForumTagSecurity = "Security"
+	ForumTagResearch = "Research"
+)
+
+// ForumTagNames are every tag a job can be given, in the order they are added to a forum.
+var ForumTagNames = []string{
+	ForumTagIntern, ForumTagNewGrad,
+	ForumTagRemote, ForumTagUS, ForumTagCanada, ForumTagEurope, ForumTagAsia,
+	ForumTagSoftware, ForumTagData, ForumTagQuant, ForumTagHardware, ForumTagProduct, ForumTagDesign, ForumTagSecurity, ForumTagResearch,
+}
+
+type tagPattern struct {
+	tag     string
+	pattern *regexp.Regexp
+}
+
+// regionPatterns are matched against a job's location.
+var regionPatterns = []tagPattern{
+	{ForumTagRemote, regexp.MustCompile(`(?i)\bremote\b`)},
+	// State and province codes are matched case-sensitively after a comma, so words like "in" and "on" are not mistaken for them.
+	{ForumTagUS, regexp.MustCompile(`(?i:\b(united states|usa|us|nyc|new york|san francisco|bay area|seattle|boston|chicago|austin)\b)|, ?(AL|AK|AZ|AR|CA|CO|CT|DE|DC|FL|GA|HI|ID|IL|IN|IA|KS|KY|LA|ME|MD|MA|MI|MN|MS|MO|MT|NE|NV|NH|NJ|NM|NY|NC|ND|OH|OK|OR|PA|RI|SC|SD|TN|TX|UT|VT|VA|WA|WV|WI|WY)\b`)},
+	{ForumTagCanada, regexp.MustCompile(`(?i:\b(canada|toronto|vancouver|montreal|waterloo|ottawa|calgary)\b)|, ?(ON|BC|QC|AB)\b`)},
+	{ForumTagEurope, regexp.MustCompile(`(?i)\b(europe|uk|united kingdom|england|london|ireland|dublin|germany|berlin|munich|france|paris|netherlands|amsterdam|switzerland|zurich|spain|madrid|poland|warsaw|sweden|stockholm)\b`)},
+	{ForumTagAsia, regexp.MustCompile(`(?i)\b(asia|india|bangalore|bengaluru|hyderabad|singapore|japan|tokyo|china|shanghai|beijing|hong kong|korea|seoul|taiwan|taipei|israel|tel aviv)\b`)},
+}
+
+// categoryPatterns are matched against a job's role and category.
+var categoryPatterns = []tagPattern{
+	{ForumTagQuant, regexp.MustCompile(`(?i)\b(quant|quantitative|trading|trader)\b`)},
+	{ForumTagData, regexp.MustCompile(`(?i)\b(data|machine learning|ml|ai|artificial intelligence|analytics)\b`)},
+	{ForumTagHardware, regexp.MustCompile(`(?i)\b(hardware|electrical|embedded|firmware|asic|fpga|silicon)\b`)},
+	{ForumTagSecurity, regexp.MustCompile(`(?i)\b(security|cyber ?security)\b`)},
+	{ForumTagProduct, regexp.MustCompile(`(?i)\b(product manage(r|ment)|program manager|apm)\b`)},
+	{ForumTagDesign, regexp.MustCompile(`(?i)\b(design|designer|ux|ui)\b`)},
+	{ForumTagResearch, regexp.MustCompile(`(?i)\b(research|researcher)\b`)},
+	{ForumTagSoftware, regexp.MustCompile(`(?i)\b(software|swe|developer|engineer|engineering|backend|back-end|frontend|front-end|full[ -]?stack|mobile|ios|android|devops|sre)\b`)},
+}
+
+// ForumTags returns the names of the tags describing a job: its type, the regions it is in and the categories of its
+// role, in that order and at most as many as a thread can have.
+func (j *Job) ForumTags() []string {
+	tags := []string{}
+
+	switch j.JobType {
+	case INTERN:
+		tags = append(tags, ForumTagIntern)
+	case NEW_GRAD:
+		tags = append(tags, ForumTagNewGrad)
+	}
+
+	for _, region := range regionPatterns {
+		if region.pattern.MatchString(j.Location) {
+			tags = append(tags, region.tag)
+		}
+	}
+
+	// Software is the catch-all for engineering roles, so it is only used when nothing more specific matches.
+	role := j.Role + " " + j.Category
+	categorized := false
+	for _, category := range categoryPatterns {
+		if category.tag == ForumTagSoftware && categorized {
+			continue
+		}
+		if category.pattern.MatchString(role) {
+			tags = append(tags, category.tag)
+			categorized = true
+		}
+	}
+
+	if len(tags) > maxAppliedTags {
+		tags = tags[:maxAppliedTags]
+	}
+
+	return tags
+}
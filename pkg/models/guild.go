@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,10 +10,18 @@ import (
 
 type Guild struct {
 	gorm.Model
-	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	GuildID          string    `json:"guildId" gorm:"unique"`
-	InternChannelID  string    `json:"internChannelId"`
-	NewGradChannelID string    `json:"newGradChannelId"`
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GuildID string    `json:"guildId" gorm:"unique"`
+	// Timezone is the IANA timezone the guild's quiet hours and batch times are in.
+	Timezone string `gorm:"default:UTC" json:"timezone"`
+	// QuietStart and QuietEnd are the local times, such as 22:00, between which no jobs are posted. Both are empty when
+	// the guild has no quiet hours.
+	QuietStart string `json:"quietStart"`
+	QuietEnd   string `json:"quietEnd"`
+	// BatchTimes are the local times jobs are posted at. When there are none, jobs are posted as soon as they are found.
+	BatchTimes StringSlice `gorm:"type:text" json:"batchTimes"`
+	// LastBatchAt is when the jobs queued for a batch time were last posted.
+	LastBatchAt *time.Time `json:"lastBatchAt"`
 
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
@@ -23,3 +32,96 @@ func (g *Guild) BeforeCreate(tx *gorm.DB) (err error) {
 	g.ID = uuid.New()
 	return
 }
+
+// Location returns the guild's timezone, falling back to UTC if it can't be loaded.
+func (g *Guild) Location() *time.Location {
+	location, err := time.LoadLocation(g.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Quiet reports whether now is within the guild's quiet hours, which wrap past midnight when they end earlier than they
+// start.
+func (g *Guild) Quiet(now time.Time) bool {
+	start, ok := ParseClock(g.QuietStart)
+	if !ok {
+		return false
+	}
+	end, ok := ParseClock(g.QuietEnd)
+	if !ok || start == end {
+		return false
+	}
+
+	local := now.In(g.Location())
+	minute := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Batched reports whether the guild's jobs are only posted at its batch times.
+func (g *Guild) Batched() bool {
+	return len(g.batchMinutes()) > 0
+}
+
+// BatchDue reports whether one of the guild's batch times has passed since its last batch was posted, or since the
+// guild was created. Guilds without batch times are always due.
+func (g *Guild) BatchDue(now time.Time) bool {
+	minutes := g.batchMinutes()
+	if len(minutes) == 0 {
+		return true
+	}
+
+	last := g.CreatedAt
+	if g.LastBatchAt != nil {
+		last = *g.LastBatchAt
+	}
+
+	local := now.In(g.Location())
+
+	for _, minute := range minutes {
+		scheduled := time.Date(local.Year(), local.Month(), local.Day(), minute/60, minute%60, 0, 0, local.Location())
+		if scheduled.After(local) {
+			scheduled = scheduled.AddDate(0, 0, -1)
+		}
+		if last.Before(scheduled) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PostingOpen reports whether the guild's feeds may post now. Jobs found while they can't are queued until they can.
+func (g *Guild) PostingOpen(now time.Time) bool {
+	return !g.Quiet(now) && g.BatchDue(now)
+}
+
+func (g *Guild) batchMinutes() []int {
+	minutes := []int{}
+	for _, value := range g.BatchTimes {
+		minute, ok := ParseClock(value)
+		if ok {
+			minutes = append(minutes, minute)
+		}
+	}
+	return minutes
+}
+
+// ParseClock parses a time of day such as 9:00 or 17:30, returning the minutes since midnight.
+func ParseClock(value string) (int, bool) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+// FormatClock formats minutes since midnight as a time of day such as 09:00.
+func FormatClock(minutes int) string {
+	return time.Date(0, 1, 1, minutes/60, minutes%60, 0, 0, time.UTC).Format("15:04")
+}
@@ -12,18 +12,31 @@ import (
 	"gorm.io/gorm"
 )
 
+type JobStatus string
+
+const (
+	JobStatusOpen     JobStatus = "open"
+	JobStatusClosed   JobStatus = "closed"
+	JobStatusReopened JobStatus = "reopened"
+)
+
 type Job struct {
 	gorm.Model
-	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	SourceURL       string    `json:"sourceURL"`
-	Source          string    `json:"source"`
-	JobType         JobType   `json:"jobType"`
-	Company         string    `json:"company"`
-	Logo            string    `json:"logo"`
-	Role            string    `json:"role"`
-	Location        string    `json:"location"`
-	ApplicationLink string    `json:"application" gorm:"unique"`
-	FirstSeen       time.Time `json:"firstSeen"`
+	ID              uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
+	SourceURL       string      `json:"sourceURL"`
+	Source          string      `json:"source"`
+	JobType         JobType     `json:"jobType"`
+	Company         string      `json:"company"`
+	Logo            string      `json:"logo"`
+	Role            string      `json:"role"`
+	Location        string      `json:"location"`
+	ApplicationLink string      `json:"application" gorm:"unique"`
+	FirstSeen       time.Time   `json:"firstSeen"`
+	Status          JobStatus   `json:"status" gorm:"default:open;index"`
+	ClosedAt        *time.Time  `json:"closedAt"`
+	Sponsorship     string      `json:"sponsorship"`
+	Terms           StringSlice `json:"terms" gorm:"type:text"`
+	Category        string      `json:"category"`
 
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
@@ -32,9 +45,73 @@ type Job struct {
 
 func (j *Job) BeforeCreate(tx *gorm.DB) (err error) {
 	j.ID = uuid.New()
+	if j.Status == "" {
+		j.Status = JobStatusOpen
+	}
 	return
 }
 
+// JobStatusChange records a single transition in a job's lifecycle.
+type JobStatusChange struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	JobID  uuid.UUID `gorm:"not null;index" json:"jobId"`
+	From   JobStatus `json:"from"`
+	To     JobStatus `json:"to"`
+	Reason string    `json:"reason"`
+
+	Job Job `gorm:"foreignKey:JobID"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (c *JobStatusChange) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = uuid.New()
+	return
+}
+
+// SetStatus transitions the job to the given status and records the change in the job's history.
+// Setting a job to the status it already has does nothing.
+func (j *Job) SetStatus(db *gorm.DB, status JobStatus, reason string) error {
+	if j.Status == status {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{"status": status}
+
+		var closedAt *time.Time
+		if status == JobStatusClosed {
+			now := time.Now()
+			closedAt = &now
+		}
+		updates["closed_at"] = closedAt
+
+		err := tx.Model(&Job{}).Where("id = ?", j.ID).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(&JobStatusChange{
+			JobID:  j.ID,
+			From:   j.Status,
+			To:     status,
+			Reason: reason,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		j.Status = status
+		j.ClosedAt = closedAt
+		return nil
+	})
+}
+
+// IsClosed reports whether the posting is no longer accepting applications.
+func (j *Job) IsClosed() bool {
+	return j.Status == JobStatusClosed
+}
+
 type ClearBitResponse struct {
 	Name   string `json:"name"`
 	Domain string `json:"domain"`
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Migrate creates or updates every table and then moves existing data into any new structures.
+func Migrate(db *gorm.DB) error {
+	err := db.AutoMigrate(&Job{}, &JobStatusChange{}, &Guild{}, &Feed{}, &RolePing{}, &SentJob{}, &Subscription{}, &SubscriptionDelivery{})
+	if err != nil {
+		return err
+	}
+
+	err = migrateSubscriptionSentJobs(db)
+	if err != nil {
+		return err
+	}
+
+	err = migrateGuildChannels(db)
+	if err != nil {
+		return err
+	}
+
+	return migrateSubscriptionHandles(db)
+}
+
+// migrateSubscriptionHandles generates a handle for every subscription created before they had one.
+func migrateSubscriptionHandles(db *gorm.DB) error {
+	var subscriptions []Subscription
+	err := db.Where("handle IS NULL OR handle = ''").Find(&subscriptions).Error
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		handle, err := newSubscriptionHandle(db, subscription.UserID)
+		if err != nil {
+			return err
+		}
+		err = db.Model(&subscription).Update("handle", handle).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateSubscriptionSentJobs moves subscription deliveries out of sent_jobs, where they used to be stored with the
+// subscription's ID in place of a guild ID.
+func migrateSubscriptionSentJobs(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT OR IGNORE INTO subscription_deliveries
+			(id, subscription_id, user_id, job_id, message_id, channel_id, status, error, marked_closed, created_at, updated_at)
+			SELECT sent_jobs.id, sent_jobs.guild_id, subscriptions.user_id, sent_jobs.job_id, sent_jobs.message_id, sent_jobs.channel_id,
+				CASE WHEN sent_jobs.error THEN ? ELSE ? END, '', sent_jobs.marked_closed, sent_jobs.created_at, sent_jobs.updated_at
+			FROM sent_jobs
+			JOIN subscriptions ON subscriptions.id = sent_jobs.guild_id`, DeliveryStatusFailed, DeliveryStatusSent).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec("DELETE FROM sent_jobs WHERE guild_id IN (SELECT id FROM subscriptions)").Error
+	})
+}
+
+// migrateGuildChannels turns the intern and new grad channels that guilds used to have into feeds, and attributes the
+// jobs already posted to them to their new feed.
+func migrateGuildChannels(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&Guild{}, "intern_channel_id") {
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var guilds []struct {
+			ID               uuid.UUID
+			CreatedAt        time.Time
+			InternChannelID  string
+			NewGradChannelID string
+		}
+		err := tx.Table("guilds").Select("id, created_at, intern_channel_id, new_grad_channel_id").Scan(&guilds).Error
+		if err != nil {
+			return err
+		}
+
+		for _, guild := range guilds {
+			defaults := []struct {
+				name      string
+				channelID string
+				jobType   JobType
+			}{
+				{FeedNameInterns, guild.InternChannelID, INTERN},
+				{FeedNameNewGrads, guild.NewGradChannelID, NEW_GRAD},
+			}
+
+			for _, feed := range defaults {
+				if feed.channelID == "" {
+					continue
+				}
+				// The feed keeps the guild's creation time, so that it doesn't skip jobs the channel hasn't been sent yet.
+				err = tx.Create(&Feed{
+					GuildID:   guild.ID,
+					Name:      feed.name,
+					ChannelID: feed.channelID,
+					Filter:    Filter{JobType: feed.jobType},
+					CreatedAt: guild.CreatedAt,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = tx.Exec(`UPDATE sent_jobs SET feed_id = (
+			SELECT feeds.id FROM feeds JOIN jobs ON jobs.id = sent_jobs.job_id
+			WHERE feeds.guild_id = sent_jobs.guild_id AND feeds.job_type = jobs.job_type
+		) WHERE feed_id IS NULL`).Error
+		if err != nil {
+			return err
+		}
+
+		// Messages sent before their channel was recorded were sent to their feed's channel.
+		return tx.Exec(`UPDATE sent_jobs SET channel_id = (SELECT feeds.channel_id FROM feeds WHERE feeds.id = sent_jobs.feed_id)
+			WHERE (channel_id IS NULL OR channel_id = '') AND feed_id IS NOT NULL`).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Migrator().DropColumn(&Guild{}, "intern_channel_id")
+	if err != nil {
+		return err
+	}
+
+	return db.Migrator().DropColumn(&Guild{}, "new_grad_channel_id")
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxRolePingsPerHour is the most roles mentioned in a channel each hour, counting both feeds' own pings and role
+// pings, so a flood of new jobs doesn't become a flood of pings.
+const MaxRolePingsPerHour = 10
+
+// RolePing mentions a role with the jobs posted to a guild's feeds that match its filter, so members can opt into
+// notifications by taking the role.
+type RolePing struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GuildID uuid.UUID `gorm:"not null;index" json:"guildId"`
+	// RoleID is the role mentioned, which has at most one ping per guild.
+	RoleID string `json:"roleId"`
+	// ChannelID limits the pings to the feeds posting in one channel, or is empty for every channel.
+	ChannelID string `json:"channelId"`
+	Filter    `gorm:"embedded"`
+
+	Guild Guild `gorm:"foreignKey:GuildID"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (r *RolePing) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	return
+}
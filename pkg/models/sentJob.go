@@ -11,9 +11,17 @@ type SentJob struct {
 	gorm.Model
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	MessageId string    `json:"messageId"`
+	ChannelID string    `json:"channelId"`
 	GuildID   uuid.UUID `gorm:"not null" json:"guildId"`
 	JobID     uuid.UUID `gorm:"not null" json:"jobId"`
 	Error     bool      `gorm:"default:false" json:"error"`
+	// FeedID is the feed the job was posted to. Each job is posted to a feed at most once.
+	FeedID *uuid.UUID `gorm:"index" json:"feedId"`
+	// PingedRoles are the roles mentioned with the job, whether the feed's own or through role pings.
+	PingedRoles StringSlice `gorm:"type:text" json:"pingedRoles"`
+	// MarkedClosed is set once the message has been edited to show that the job closed, and cleared once it has been
+	// restored after the job reopened.
+	MarkedClosed bool `gorm:"default:false" json:"markedClosed"`
 
 	Guild Guild `gorm:"foreignKey:GuildID"`
 	Job   Job   `gorm:"foreignKey:JobID"`
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
 type JobType string
 
 const (
@@ -7,14 +13,135 @@ const (
 	INTERN   JobType = "INTERN"
 )
 
+type SiteFormat string
+
+const (
+	// SiteFormatRegex sites are parsed with their RegexPattern.
+	SiteFormatRegex SiteFormat = "regex"
+	// SiteFormatTable sites are markdown or HTML READMEs listing jobs in tables.
+	SiteFormatTable SiteFormat = "table"
+)
+
 type Site struct {
-	Name                 string  `json:"name"`
-	URL                  string  `json:"url"`
-	RegexPattern         string  `json:"regexPattern"`
-	JobType              JobType `json:"type"`
-	CompanyGroup         int     `json:"companyGroup"`
-	RoleGroup            int     `json:"roleGroup"`
-	LocationGroup        int     `json:"locationGroup"`
-	ApplicationLinkGroup int     `json:"applicationLinkGroup"`
-	AgeGroup             int     `json:"ageGroup"`
+	Name                 string     `json:"name"`
+	URL                  string     `json:"url"`
+	Format               SiteFormat `json:"format"`
+	RegexPattern         string     `json:"regexPattern"`
+	JobType              JobType    `json:"type"`
+	CompanyGroup         int        `json:"companyGroup"`
+	RoleGroup            int        `json:"roleGroup"`
+	LocationGroup        int        `json:"locationGroup"`
+	ApplicationLinkGroup int        `json:"applicationLinkGroup"`
+	AgeGroup             int        `json:"ageGroup"`
+}
+
+// Validate ensures the site can be scraped, returning an error that names the site otherwise.
+func (s *Site) Validate() error {
+	if s.Name == "" {
+		return errors.New("site is missing a name")
+	}
+
+	if s.URL == "" {
+		return fmt.Errorf("site %q is missing a url", s.Name)
+	}
+
+	if s.JobType != INTERN && s.JobType != NEW_GRAD {
+		return fmt.Errorf("site %q has an invalid type %q, expected %q or %q", s.Name, s.JobType, INTERN, NEW_GRAD)
+	}
+
+	switch s.Format {
+	case "", SiteFormatRegex:
+	case SiteFormatTable:
+		return nil
+	default:
+		return fmt.Errorf("site %q has an invalid format %q, expected %q or %q", s.Name, s.Format, SiteFormatRegex, SiteFormatTable)
+	}
+
+	regex, err := regexp.Compile(s.RegexPattern)
+	if err != nil {
+		return fmt.Errorf("site %q has an invalid regexPattern: %w", s.Name, err)
+	}
+
+	groups := map[string]int{
+		"companyGroup":         groupOrDefault(s.CompanyGroup, 1),
+		"roleGroup":            groupOrDefault(s.RoleGroup, 2),
+		"locationGroup":        groupOrDefault(s.LocationGroup, 3),
+		"applicationLinkGroup": groupOrDefault(s.ApplicationLinkGroup, 4),
+		"ageGroup":             s.AgeGroup,
+	}
+
+	for name, group := range groups {
+		if group > regex.NumSubexp() {
+			return fmt.Errorf("site %q has %s %d, but its regexPattern only has %d groups", s.Name, name, group, regex.NumSubexp())
+		}
+	}
+
+	return nil
+}
+
+// groupOrDefault mirrors the group defaults applied by scraper.Scrape.
+func groupOrDefault(group int, def int) int {
+	if group == 0 {
+		return def
+	}
+	return group
+}
+
+// Board is a company job board hosted on an applicant tracking system such as Greenhouse.
+type Board struct {
+	Token   string `json:"token"`
+	Company string `json:"company"`
+}
+
+// WorkdaySite is a company career site hosted on Workday, e.g. https://<host>/<site>.
+type WorkdaySite struct {
+	Company    string `json:"company"`
+	Host       string `json:"host"`
+	Tenant     string `json:"tenant"`
+	Site       string `json:"site"`
+	SearchText string `json:"searchText"`
+	MaxJobs    int    `json:"maxJobs"`
+}
+
+// RSSFeed is an RSS 2.0 or Atom feed of job postings.
+// TitlePattern may use the named groups company, role and location to extract fields from each item's title.
+type RSSFeed struct {
+	Name         string  `json:"name"`
+	URL          string  `json:"url"`
+	JobType      JobType `json:"type"`
+	TitlePattern string  `json:"titlePattern"`
+	Company      string  `json:"company"`
+	Location     string  `json:"location"`
+}
+
+// Validate ensures the feed can be scraped, returning an error that names the feed otherwise.
+func (f *RSSFeed) Validate() error {
+	if f.Name == "" {
+		return errors.New("feed is missing a name")
+	}
+
+	if f.URL == "" {
+		return fmt.Errorf("feed %q is missing a url", f.Name)
+	}
+
+	if f.JobType != "" && f.JobType != INTERN && f.JobType != NEW_GRAD {
+		return fmt.Errorf("feed %q has an invalid type %q, expected %q or %q", f.Name, f.JobType, INTERN, NEW_GRAD)
+	}
+
+	if f.TitlePattern == "" {
+		return nil
+	}
+
+	regex, err := regexp.Compile(f.TitlePattern)
+	if err != nil {
+		return fmt.Errorf("feed %q has an invalid titlePattern: %w", f.Name, err)
+	}
+
+	for _, name := range regex.SubexpNames() {
+		if name == "company" || name == "role" || name == "location" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("feed %q has a titlePattern without a company, role or location group", f.Name)
 }
@@ -3,6 +3,8 @@ package models
 import (
 	"database/sql/driver"
 	"errors"
+	"math/rand/v2"
+	"regexp"
 	"strings"
 	"time"
 
@@ -17,17 +19,39 @@ const (
 	QueryOperatorOr  QueryOperator = "OR"
 )
 
+// DeliveryMode is how often a subscription's matched jobs are sent.
+type DeliveryMode string
+
+const (
+	DeliveryModeInstant DeliveryMode = "instant"
+	DeliveryModeDaily   DeliveryMode = "daily"
+	DeliveryModeWeekly  DeliveryMode = "weekly"
+)
+
 type StringSlice []string
 
 type Subscription struct {
 	gorm.Model
-	ID        uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
-	JobType   JobType     `json:"jobType"`
-	UserID    string      `json:"userId"`
-	Roles     StringSlice `json:"roles" gorm:"type:text"`
-	Companies StringSlice `json:"companies" gorm:"type:text"`
-	Locations StringSlice `json:"locations" gorm:"type:text"`
-	Active    bool        `json:"active" gorm:"default:true"`
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID string    `json:"userId"`
+	// Handle identifies the subscription among the user's others. It is chosen by the user or generated, and never
+	// changes, unlike the subscription's position in /subscriptions.
+	Handle string `json:"handle" gorm:"index"`
+	Filter `gorm:"embedded"`
+	Active bool `json:"active" gorm:"default:true"`
+	// Delivery is how often matched jobs are sent. Daily and weekly digests are sent at DeliveryHour in Timezone.
+	Delivery     DeliveryMode `json:"delivery" gorm:"default:instant"`
+	DeliveryHour int          `json:"deliveryHour"`
+	Timezone     string       `json:"timezone" gorm:"default:UTC"`
+	// LastDigestAt is when the last digest was sent, or nil if none has been sent yet.
+	LastDigestAt *time.Time `json:"lastDigestAt"`
+	// PausedUntil is when a paused subscription resumes by itself, or nil if it stays paused until /resume.
+	PausedUntil *time.Time `json:"pausedUntil"`
+	// SkipWhilePaused drops the jobs found while the subscription was paused, instead of sending them once resumed.
+	SkipWhilePaused bool `json:"skipWhilePaused"`
+	// SkippedUntil is when the last pause that skipped the jobs found during it was resumed. Jobs found before it are
+	// never sent, however later pauses are set.
+	SkippedUntil *time.Time `json:"skippedUntil"`
 
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
@@ -37,9 +61,120 @@ type Subscription struct {
 func (s *Subscription) BeforeCreate(tx *gorm.DB) (err error) {
 	s.ID = uuid.New()
 	s.Active = true
+	if s.Handle == "" {
+		s.Handle, err = newSubscriptionHandle(tx, s.UserID)
+	}
 	return
 }
 
+const handleAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+var handleRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// ValidHandle reports whether a user chosen handle, for a subscription or feed, can be used. Handles are lowercase
+// letters, digits and dashes, and can't be only digits, which would be confused with positions in /subscriptions.
+func ValidHandle(handle string) bool {
+	return handleRegex.MatchString(handle) && strings.Trim(handle, "0123456789") != ""
+}
+
+// newSubscriptionHandle generates a short handle that none of the user's other subscriptions have.
+func newSubscriptionHandle(db *gorm.DB, userID string) (string, error) {
+	for {
+		handle := make([]byte, 5)
+		for i := range handle {
+			handle[i] = handleAlphabet[rand.IntN(len(handleAlphabet))]
+		}
+
+		var count int64
+		err := db.Model(&Subscription{}).Where("user_id = ? AND handle = ? AND deleted_at IS NULL", userID, string(handle)).Count(&count).Error
+		if err != nil {
+			return "", err
+		}
+		if count == 0 && ValidHandle(string(handle)) {
+			return string(handle), nil
+		}
+	}
+}
+
+// IsDigest reports whether matched jobs are bundled into a digest rather than sent as they are found.
+func (s *Subscription) IsDigest() bool {
+	return s.Delivery == DeliveryModeDaily || s.Delivery == DeliveryModeWeekly
+}
+
+// Location returns the subscription's timezone, falling back to UTC if it is unset or unknown.
+func (s *Subscription) Location() *time.Location {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// DigestDue reports whether a digest should be sent, which is once the most recent scheduled delivery has passed
+// since the last digest, or since the subscription was created. Weekly digests are scheduled on Mondays.
+func (s *Subscription) DigestDue(now time.Time) bool {
+	if !s.IsDigest() {
+		return false
+	}
+
+	local := now.In(s.Location())
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), s.DeliveryHour, 0, 0, 0, local.Location())
+	if scheduled.After(local) {
+		scheduled = scheduled.AddDate(0, 0, -1)
+	}
+	if s.Delivery == DeliveryModeWeekly {
+		for scheduled.Weekday() != time.Monday {
+			scheduled = scheduled.AddDate(0, 0, -1)
+		}
+	}
+
+	last := s.CreatedAt
+	if s.LastDigestAt != nil {
+		last = *s.LastDigestAt
+	}
+
+	return last.Before(scheduled)
+}
+
+// Pause stops jobs from being sent until the subscription is resumed, which happens by itself at until if it is given.
+func (s *Subscription) Pause(db *gorm.DB, until *time.Time, skip bool) error {
+	s.Active = false
+	s.PausedUntil = until
+	s.SkipWhilePaused = skip
+	return db.Model(s).Select("Active", "PausedUntil", "SkipWhilePaused").Updates(s).Error
+}
+
+// Resume starts sending jobs again.
+func (s *Subscription) Resume(db *gorm.DB) error {
+	if !s.Active && s.SkipWhilePaused {
+		now := time.Now()
+		s.SkippedUntil = &now
+	}
+	s.Active = true
+	s.PausedUntil = nil
+	return db.Model(s).Select("Active", "PausedUntil", "SkippedUntil").Updates(s).Error
+}
+
+// ResumeExpiredPauses resumes every paused subscription whose PausedUntil has passed, as if resumed at that time.
+func ResumeExpiredPauses(db *gorm.DB, now time.Time) error {
+	return db.Model(&Subscription{}).
+		Where("active = ? AND paused_until IS NOT NULL AND paused_until <= ?", false, now).
+		Updates(map[string]any{
+			"active":        true,
+			"skipped_until": gorm.Expr("CASE WHEN skip_while_paused THEN paused_until ELSE skipped_until END"),
+			"paused_until":  nil,
+		}).Error
+}
+
+// DeliverSince returns the time after which found jobs are sent: when the subscription was created, or when a pause
+// that skipped the jobs found during it was last resumed.
+func (s *Subscription) DeliverSince() time.Time {
+	if s.SkippedUntil != nil && s.SkippedUntil.After(s.CreatedAt) {
+		return *s.SkippedUntil
+	}
+	return s.CreatedAt
+}
+
 func (s StringSlice) Value() (driver.Value, error) {
 	if len(s) == 0 {
 		return nil, nil
@@ -48,6 +183,10 @@ func (s StringSlice) Value() (driver.Value, error) {
 }
 
 func (s *StringSlice) Scan(src any) error {
+	if src == nil {
+		*s = nil
+		return nil
+	}
 	bytes, ok := src.(string)
 	if !ok {
 		return errors.New("src value cannot cast to []byte")
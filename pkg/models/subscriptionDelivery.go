@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent   DeliveryStatus = "sent"
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// SubscriptionDelivery records a job sent, or attempted to be sent, to a user through their subscriptions.
+// A job is delivered to each user at most once, however many of their subscriptions match it.
+type SubscriptionDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	SubscriptionID uuid.UUID `gorm:"not null;index" json:"subscriptionId"`
+	UserID         string    `gorm:"not null;uniqueIndex:idx_user_job" json:"userId"`
+	JobID          uuid.UUID `gorm:"not null;uniqueIndex:idx_user_job" json:"jobId"`
+	// MatchedSubscriptions are the IDs of every subscription of the user that matched the job.
+	MatchedSubscriptions StringSlice    `json:"matchedSubscriptions" gorm:"type:text"`
+	MessageID            string         `json:"messageId"`
+	ChannelID            string         `json:"channelId"`
+	Status               DeliveryStatus `json:"status"`
+	Error                string         `json:"error"`
+	// MarkedClosed is set once the message has been edited to show that the job closed, and cleared once it has been
+	// restored after the job reopened.
+	MarkedClosed bool `gorm:"default:false" json:"markedClosed"`
+	// Digest is set when the job was sent as part of a digest, whose message lists other jobs as well.
+	Digest bool `gorm:"default:false" json:"digest"`
+
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID"`
+	Job          Job          `gorm:"foreignKey:JobID"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (d *SubscriptionDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	d.ID = uuid.New()
+	return
+}
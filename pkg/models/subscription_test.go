@@ -0,0 +1,94 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeliverSinceKeepsSkippedPauses(t *testing.T) {
+	db := newTestDB(t)
+
+	subscription := Subscription{UserID: "user", Filter: Filter{JobType: INTERN}}
+	err := db.Create(&subscription).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !subscription.DeliverSince().Equal(subscription.CreatedAt) {
+		t.Errorf("expected jobs since the subscription was created, got %s", subscription.DeliverSince())
+	}
+
+	// Skip the jobs found during the first pause, then send the ones found during the second.
+	err = subscription.Pause(db, nil, true)
+	if err == nil {
+		err = subscription.Resume(db)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	skippedUntil := subscription.DeliverSince()
+
+	err = subscription.Pause(db, nil, false)
+	if err == nil {
+		err = subscription.Resume(db)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved Subscription
+	err = db.Where("id = ?", subscription.ID).First(&saved).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !saved.DeliverSince().After(saved.CreatedAt) || !saved.DeliverSince().Equal(skippedUntil) {
+		t.Errorf("expected jobs since the skipped pause was resumed at %s, got %s", skippedUntil, saved.DeliverSince())
+	}
+}
+
+func TestResumeExpiredPauses(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Now()
+	until := now.Add(-time.Hour)
+
+	skipped := Subscription{UserID: "user", Filter: Filter{JobType: INTERN}}
+	sent := Subscription{UserID: "user", Filter: Filter{JobType: NEW_GRAD}}
+	for _, subscription := range []*Subscription{&skipped, &sent} {
+		err := db.Create(subscription).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := skipped.Pause(db, &until, true)
+	if err == nil {
+		err = sent.Pause(db, &until, false)
+	}
+	if err == nil {
+		err = ResumeExpiredPauses(db, now)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := make([]Subscription, 2)
+	for i, subscription := range []*Subscription{&skipped, &sent} {
+		err = db.Where("id = ?", subscription.ID).First(&resumed[i]).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !resumed[i].Active || resumed[i].PausedUntil != nil {
+			t.Errorf("expected subscription %s to be resumed", resumed[i].Handle)
+		}
+	}
+	skipped, sent = resumed[0], resumed[1]
+
+	if skipped.SkippedUntil == nil || !skipped.SkippedUntil.Equal(until) {
+		t.Errorf("expected the skipping pause to be skipped until %s, got %v", until, skipped.SkippedUntil)
+	}
+	if sent.SkippedUntil != nil {
+		t.Errorf("expected the sending pause to skip nothing, got %v", sent.SkippedUntil)
+	}
+}
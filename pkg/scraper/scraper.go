@@ -18,6 +18,27 @@ type Scraper interface {
 	Scrape() ([]models.Job, error)
 }
 
+type siteScraper struct {
+	site     models.Site
+	db       *gorm.DB
+	jobEvent *chan models.Job
+	log      *zap.SugaredLogger
+}
+
+// NewSiteScraper wraps a configured Site so that it can be registered alongside the other scrapers.
+func NewSiteScraper(site models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.SugaredLogger) *siteScraper {
+	return &siteScraper{
+		site:     site,
+		db:       db,
+		jobEvent: jobEvent,
+		log:      log,
+	}
+}
+
+func (ss *siteScraper) Scrape() ([]models.Job, error) {
+	return Scrape(&ss.site, ss.db, ss.jobEvent, ss.log)
+}
+
 // Scrape first scrapes the site and parses the page for relevant information.
 // It then creates a new Job and attempts to add it to the database.
 // If there is a UNIQUE constraint violation, the job is skipped.
@@ -57,7 +78,7 @@ func Scrape(s *models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.Sug
 
 	if resp.StatusCode != http.StatusOK {
 		log.Errorf("Received non-200 status code: %d", resp.StatusCode)
-		return nil, err
+		return nil, fmt.Errorf("received non-200 status code from %s: %d", s.URL, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -67,11 +88,17 @@ func Scrape(s *models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.Sug
 		return nil, err
 	}
 
-	regex := regexp.MustCompile(s.RegexPattern)
+	regex, err := regexp.Compile(s.RegexPattern)
+
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
 
 	matches := regex.FindAllStringSubmatch(string(body), -1)
 
 	jobs := []models.Job{}
+	openLinks := []string{}
 
 	slices.Reverse(matches)
 
@@ -97,6 +124,8 @@ func Scrape(s *models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.Sug
 			applicationLinkGroup = s.ApplicationLinkGroup
 		}
 
+		openLinks = append(openLinks, match[applicationLinkGroup])
+
 		job := models.Job{
 			SourceURL:       s.URL,
 			Source:          s.Name,
@@ -122,11 +151,15 @@ func Scrape(s *models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.Sug
 		}
 
 		next := i + 1
-		for job.Company == "" {
+		for job.Company == "" && next < len(matches) {
 			job.Company = matches[next][companyGroup]
 			next += 1
 		}
 
+		if job.Company == "" {
+			continue
+		}
+
 		re := regexp.MustCompile(`<[^>]*>`)
 		cleanedString := re.ReplaceAllString(job.Location, " ")
 
@@ -156,6 +189,8 @@ func Scrape(s *models.Site, db *gorm.DB, jobEvent *chan models.Job, log *zap.Sug
 		jobs = append(jobs, job)
 	}
 
+	SyncJobStatuses(db, log, s.URL, true, openLinks, nil)
+
 	return jobs, nil
 
 }
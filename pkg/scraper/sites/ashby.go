@@ -0,0 +1,145 @@
+package sites
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const ashbyURL = "https://api.ashbyhq.com"
+
+type ashbyJob struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	EmploymentType     string `json:"employmentType"`
+	Location           string `json:"location"`
+	SecondaryLocations []struct {
+		Location string `json:"location"`
+	} `json:"secondaryLocations"`
+	IsListed    bool   `json:"isListed"`
+	PublishedAt string `json:"publishedAt"`
+	JobURL      string `json:"jobUrl"`
+}
+
+type ashbyBoard struct {
+	Jobs []ashbyJob `json:"jobs"`
+}
+
+type ashby struct {
+	log           *zap.SugaredLogger
+	db            *gorm.DB
+	jobChan       *chan models.Job
+	organizations []models.Board
+	baseURL       string
+	client        *http.Client
+}
+
+func NewAshby(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, organizations []models.Board) *ashby {
+	return &ashby{
+		log:           log,
+		db:            db,
+		jobChan:       jobChan,
+		organizations: organizations,
+		baseURL:       ashbyURL,
+		client:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (a *ashby) Scrape() ([]models.Job, error) {
+	jobs := []models.Job{}
+
+	for _, organization := range a.organizations {
+		boardURL := a.boardURL(organization)
+		a.log.Infof("Starting Scrape: %s", boardURL)
+
+		localJobs, openLinks, closedLinks, err := a.scrapeOrganization(organization, boardURL)
+		if err != nil {
+			a.log.Errorf("Error scraping Ashby organization %s: %v", organization.Token, err)
+			continue
+		}
+
+		jobs = append(jobs, saveJobs(a.db, a.log, a.jobChan, localJobs)...)
+
+		scraper.SyncJobStatuses(a.db, a.log, boardURL, true, openLinks, closedLinks)
+
+		a.log.Infof("Finished Scrape: %s | %d jobs", boardURL, len(localJobs))
+	}
+
+	return jobs, nil
+}
+
+func (a *ashby) boardURL(organization models.Board) string {
+	return fmt.Sprintf("%s/posting-api/job-board/%s", a.baseURL, url.PathEscape(organization.Token))
+}
+
+// scrapeOrganization returns the relevant jobs, along with the links of every listed and unlisted posting.
+func (a *ashby) scrapeOrganization(organization models.Board, boardURL string) ([]models.Job, []string, []string, error) {
+	req, err := http.NewRequest(http.MethodGet, boardURL, nil)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	response := ashbyBoard{}
+
+	err = fetchJSON(a.client, req, &response)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	name := organization.Company
+	if name == "" {
+		name = organization.Token
+	}
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+	closedLinks := []string{}
+
+	for _, job := range response.Jobs {
+		if !job.IsListed {
+			closedLinks = append(closedLinks, job.JobURL)
+			continue
+		}
+		openLinks = append(openLinks, job.JobURL)
+
+		jobType, ok := ClassifyJobType(job.Title)
+		if !ok && job.EmploymentType == "Intern" {
+			jobType, ok = models.INTERN, true
+		}
+		if !ok {
+			continue
+		}
+
+		locations := []string{job.Location}
+		for _, secondary := range job.SecondaryLocations {
+			locations = append(locations, secondary.Location)
+		}
+
+		firstSeen, err := time.Parse(time.RFC3339, job.PublishedAt)
+		if err != nil {
+			firstSeen = time.Now()
+		}
+
+		localJobs = append(localJobs, models.Job{
+			Company:         name,
+			Location:        strings.Join(locations, ", "),
+			Role:            job.Title,
+			JobType:         jobType,
+			ApplicationLink: job.JobURL,
+			FirstSeen:       firstSeen,
+			Source:          "Ashby",
+			SourceURL:       boardURL,
+		})
+	}
+
+	return localJobs, openLinks, closedLinks, nil
+}
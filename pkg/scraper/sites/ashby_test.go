@@ -0,0 +1,123 @@
+package sites
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+)
+
+func newFixtureAshby(t *testing.T) (*ashby, models.Board) {
+	t.Helper()
+
+	server := newFixtureServer(t, "GET /posting-api/job-board/lumen", "ashby_board.json")
+
+	a := NewAshby(newTestLogger(), newTestDB(t), nil, nil)
+	a.baseURL = server.URL
+
+	return a, models.Board{Token: "lumen", Company: "Lumen"}
+}
+
+func TestAshbyScrapeOrganization(t *testing.T) {
+	a, organization := newFixtureAshby(t)
+
+	jobs, openLinks, closedLinks, err := a.scrapeOrganization(organization, a.boardURL(organization))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(openLinks) != 3 || len(closedLinks) != 1 {
+		t.Errorf("expected 3 listed and 1 unlisted posting, got %d and %d", len(openLinks), len(closedLinks))
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected the unlisted and full-time postings to be left out, got %d jobs", len(jobs))
+	}
+
+	intern := jobs[0]
+	if intern.JobType != models.INTERN || intern.Company != "Lumen" || intern.Source != "Ashby" {
+		t.Errorf("unexpected job %+v", intern)
+	}
+	if intern.Location != "San Francisco, CA, New York, NY, Remote (US)" {
+		t.Errorf("expected the secondary locations to be included, got %q", intern.Location)
+	}
+	if want := time.Date(2026, 9, 10, 16, 20, 0, 0, time.UTC); !intern.FirstSeen.Equal(want) {
+		t.Errorf("expected FirstSeen from publishedAt, got %s", intern.FirstSeen)
+	}
+
+	// The title alone doesn't say this is an internship, so the employment type does.
+	if jobs[1].JobType != models.INTERN {
+		t.Errorf("expected an internship from the employment type, got %q", jobs[1].JobType)
+	}
+}
+
+func TestAshbyClosesUnlistedPostings(t *testing.T) {
+	a, organization := newFixtureAshby(t)
+	boardURL := a.boardURL(organization)
+
+	saved := models.Job{
+		Company:         "Lumen",
+		Role:            "New Grad Software Engineer",
+		JobType:         models.NEW_GRAD,
+		ApplicationLink: "https://jobs.ashbyhq.com/lumen/5c1e1a2b-0004",
+		FirstSeen:       time.Now(),
+		Source:          "Ashby",
+		SourceURL:       boardURL,
+	}
+	err := a.db.Create(&saved).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, openLinks, closedLinks, err := a.scrapeOrganization(organization, boardURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scraper.SyncJobStatuses(a.db, a.log, boardURL, true, openLinks, closedLinks)
+
+	var job models.Job
+	err = a.db.Where("id = ?", saved.ID).First(&job).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !job.IsClosed() || job.ClosedAt == nil {
+		t.Errorf("expected the unlisted posting to be closed, got status %q", job.Status)
+	}
+}
+
+func TestAshbySkipsSavedPostings(t *testing.T) {
+	a, organization := newFixtureAshby(t)
+
+	jobs, _, _, err := a.scrapeOrganization(organization, a.boardURL(organization))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving the same posting again is skipped by its ApplicationLink, without sourcing a logo or notifying anyone.
+	err = a.db.Create(&models.Job{
+		Company:         "Lumen",
+		Role:            jobs[0].Role,
+		JobType:         jobs[0].JobType,
+		ApplicationLink: jobs[0].ApplicationLink,
+		FirstSeen:       jobs[0].FirstSeen,
+		Source:          "Ashby",
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobChan := make(chan models.Job, 1)
+	saved := saveJobs(a.db, a.log, &jobChan, jobs[:1])
+
+	if len(saved) != 0 || len(jobChan) != 0 {
+		t.Errorf("expected the saved posting to be skipped, got %d saved", len(saved))
+	}
+
+	var count int64
+	a.db.Model(&models.Job{}).Where("application_link = ?", jobs[0].ApplicationLink).Count(&count)
+	if count != 1 {
+		t.Errorf("expected 1 job with the link, got %d", count)
+	}
+}
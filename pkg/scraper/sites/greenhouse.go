@@ -0,0 +1,143 @@
+package sites
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const greenhouseURL = "https://boards-api.greenhouse.io"
+
+type greenhouseJob struct {
+	ID             int64  `json:"id"`
+	Title          string `json:"title"`
+	AbsoluteURL    string `json:"absolute_url"`
+	CompanyName    string `json:"company_name"`
+	UpdatedAt      string `json:"updated_at"`
+	FirstPublished string `json:"first_published"`
+	Location       struct {
+		Name string `json:"name"`
+	} `json:"location"`
+}
+
+type greenhouseBoard struct {
+	Jobs []greenhouseJob `json:"jobs"`
+}
+
+type greenhouse struct {
+	log     *zap.SugaredLogger
+	db      *gorm.DB
+	jobChan *chan models.Job
+	boards  []models.Board
+	baseURL string
+	client  *http.Client
+}
+
+func NewGreenhouse(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, boards []models.Board) *greenhouse {
+	return &greenhouse{
+		log:     log,
+		db:      db,
+		jobChan: jobChan,
+		boards:  boards,
+		baseURL: greenhouseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (g *greenhouse) Scrape() ([]models.Job, error) {
+	jobs := []models.Job{}
+
+	for _, board := range g.boards {
+		boardURL := g.boardURL(board)
+		g.log.Infof("Starting Scrape: %s", boardURL)
+
+		localJobs, openLinks, err := g.scrapeBoard(board, boardURL)
+		if err != nil {
+			// A single misconfigured or unavailable board should not prevent the rest from being scraped.
+			g.log.Errorf("Error scraping Greenhouse board %s: %v", board.Token, err)
+			continue
+		}
+
+		jobs = append(jobs, saveJobs(g.db, g.log, g.jobChan, localJobs)...)
+
+		scraper.SyncJobStatuses(g.db, g.log, boardURL, true, openLinks, nil)
+
+		g.log.Infof("Finished Scrape: %s | %d jobs", boardURL, len(localJobs))
+	}
+
+	return jobs, nil
+}
+
+func (g *greenhouse) boardURL(board models.Board) string {
+	return fmt.Sprintf("%s/v1/boards/%s/jobs", g.baseURL, url.PathEscape(board.Token))
+}
+
+// scrapeBoard returns the relevant jobs on the board, along with the links of every posting that is still listed.
+func (g *greenhouse) scrapeBoard(board models.Board, boardURL string) ([]models.Job, []string, error) {
+	req, err := http.NewRequest(http.MethodGet, boardURL, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := greenhouseBoard{}
+
+	err = fetchJSON(g.client, req, &response)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+
+	for _, job := range response.Jobs {
+		openLinks = append(openLinks, job.AbsoluteURL)
+
+		jobType, ok := ClassifyJobType(job.Title)
+		if !ok {
+			continue
+		}
+
+		company := job.CompanyName
+		if company == "" {
+			company = board.Company
+		}
+		if company == "" {
+			company = board.Token
+		}
+
+		localJobs = append(localJobs, models.Job{
+			Company:         company,
+			Location:        job.Location.Name,
+			Role:            job.Title,
+			JobType:         jobType,
+			ApplicationLink: job.AbsoluteURL,
+			FirstSeen:       greenhouseFirstSeen(job),
+			Source:          "Greenhouse",
+			SourceURL:       boardURL,
+		})
+	}
+
+	return localJobs, openLinks, nil
+}
+
+// greenhouseFirstSeen prefers the date the posting was first published, falling back to the last update.
+func greenhouseFirstSeen(job greenhouseJob) time.Time {
+	for _, value := range []string{job.FirstPublished, job.UpdatedAt} {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
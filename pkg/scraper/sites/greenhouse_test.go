@@ -0,0 +1,85 @@
+package sites
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+func newFixtureGreenhouse(t *testing.T) *greenhouse {
+	t.Helper()
+
+	server := newFixtureServer(t, "GET /v1/boards/acme/jobs", "greenhouse_board.json")
+
+	g := NewGreenhouse(newTestLogger(), newTestDB(t), nil, nil)
+	g.baseURL = server.URL
+
+	return g
+}
+
+func TestGreenhouseScrapeBoard(t *testing.T) {
+	g := newFixtureGreenhouse(t)
+
+	board := models.Board{Token: "acme", Company: "Acme"}
+	boardURL := g.boardURL(board)
+	jobs, links, err := g.scrapeBoard(board, boardURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(links) != 3 {
+		t.Errorf("expected every posting's link, got %d", len(links))
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected the senior role to be left out, got %d jobs", len(jobs))
+	}
+
+	intern, newGrad := jobs[0], jobs[1]
+
+	if intern.JobType != models.INTERN || newGrad.JobType != models.NEW_GRAD {
+		t.Errorf("expected an internship and a new grad position, got %q and %q", intern.JobType, newGrad.JobType)
+	}
+
+	if intern.Company != "Acme Robotics" {
+		t.Errorf("expected the posting's company name, got %q", intern.Company)
+	}
+	if newGrad.Company != "Acme" {
+		t.Errorf("expected the board's company without a company name, got %q", newGrad.Company)
+	}
+
+	if want := time.Date(2026, 9, 2, 18, 5, 11, 0, time.UTC); !intern.FirstSeen.Equal(want) {
+		t.Errorf("expected FirstSeen from first_published, got %s", intern.FirstSeen)
+	}
+	if want := time.Date(2026, 9, 15, 16, 30, 0, 0, time.UTC); !newGrad.FirstSeen.Equal(want) {
+		t.Errorf("expected FirstSeen from updated_at without first_published, got %s", newGrad.FirstSeen)
+	}
+
+	if intern.Source != "Greenhouse" || intern.SourceURL != boardURL || intern.Location != "New York, NY" {
+		t.Errorf("unexpected job %+v", intern)
+	}
+}
+
+func TestGreenhouseCompanyFallsBackToToken(t *testing.T) {
+	g := newFixtureGreenhouse(t)
+
+	board := models.Board{Token: "acme"}
+	jobs, _, err := g.scrapeBoard(board, g.boardURL(board))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 2 || jobs[1].Company != "acme" {
+		t.Errorf("expected the board token as the company, got %+v", jobs)
+	}
+}
+
+func TestGreenhouseScrapeBoardError(t *testing.T) {
+	g := newFixtureGreenhouse(t)
+
+	board := models.Board{Token: "missing"}
+	_, _, err := g.scrapeBoard(board, g.boardURL(board))
+	if err == nil {
+		t.Error("expected an error for a board that doesn't exist")
+	}
+}
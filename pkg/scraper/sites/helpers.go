@@ -0,0 +1,86 @@
+package sites
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	internRegex  = regexp.MustCompile(`(?i)\b(intern|interns|internship|internships|co-?op|co-?ops)\b`)
+	newGradRegex = regexp.MustCompile(`(?i)\b(new[ -]?grad|new[ -]?graduate|recent[ -]graduate|university[ -]graduate|college[ -]graduate|entry[ -]level|early[ -]career)\b`)
+)
+
+// ClassifyJobType determines whether a posting is an internship or a new grad position based on its title.
+// The second return value is false if the posting is neither.
+func ClassifyJobType(title string) (models.JobType, bool) {
+	if internRegex.MatchString(title) {
+		return models.INTERN, true
+	}
+
+	if newGradRegex.MatchString(title) {
+		return models.NEW_GRAD, true
+	}
+
+	return "", false
+}
+
+// fetchJSON performs the request and decodes the JSON response body into v.
+func fetchJSON(client *http.Client, req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 status code from %s: %d", req.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// saveJobs attempts to add each job to the database.
+// Jobs whose ApplicationLink already exists are skipped.
+// Newly saved jobs have their logo sourced and are sent through the jobChan, and are returned.
+func saveJobs(db *gorm.DB, log *zap.SugaredLogger, jobChan *chan models.Job, jobs []models.Job) []models.Job {
+	saved := []models.Job{}
+
+	for _, job := range jobs {
+		err := db.Save(&job).Error
+
+		if err != nil {
+			if err == gorm.ErrDuplicatedKey {
+				continue
+			}
+			log.Error(err)
+			continue
+		}
+
+		job.SourceLogo(db)
+
+		if jobChan != nil {
+			*jobChan <- job
+		}
+
+		saved = append(saved, job)
+	}
+
+	return saved
+}
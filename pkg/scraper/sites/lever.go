@@ -0,0 +1,130 @@
+package sites
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const leverURL = "https://api.lever.co"
+
+type leverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	HostedURL  string `json:"hostedUrl"`
+	CreatedAt  int64  `json:"createdAt"`
+	Categories struct {
+		Commitment string `json:"commitment"`
+		Location   string `json:"location"`
+		Team       string `json:"team"`
+	} `json:"categories"`
+}
+
+type lever struct {
+	log       *zap.SugaredLogger
+	db        *gorm.DB
+	jobChan   *chan models.Job
+	companies []models.Board
+	baseURL   string
+	client    *http.Client
+}
+
+func NewLever(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, companies []models.Board) *lever {
+	return &lever{
+		log:       log,
+		db:        db,
+		jobChan:   jobChan,
+		companies: companies,
+		baseURL:   leverURL,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (l *lever) Scrape() ([]models.Job, error) {
+	jobs := []models.Job{}
+
+	for _, company := range l.companies {
+		postingsURL := l.postingsURL(company)
+		l.log.Infof("Starting Scrape: %s", postingsURL)
+
+		localJobs, openLinks, err := l.scrapeCompany(company, postingsURL)
+		if err != nil {
+			l.log.Errorf("Error scraping Lever company %s: %v", company.Token, err)
+			continue
+		}
+
+		jobs = append(jobs, saveJobs(l.db, l.log, l.jobChan, localJobs)...)
+
+		scraper.SyncJobStatuses(l.db, l.log, postingsURL, true, openLinks, nil)
+
+		l.log.Infof("Finished Scrape: %s | %d jobs", postingsURL, len(localJobs))
+	}
+
+	return jobs, nil
+}
+
+func (l *lever) postingsURL(company models.Board) string {
+	return fmt.Sprintf("%s/v0/postings/%s?mode=json", l.baseURL, url.PathEscape(company.Token))
+}
+
+// scrapeCompany returns the relevant postings, along with the links of every posting that is still listed.
+func (l *lever) scrapeCompany(company models.Board, postingsURL string) ([]models.Job, []string, error) {
+	req, err := http.NewRequest(http.MethodGet, postingsURL, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	postings := []leverPosting{}
+
+	err = fetchJSON(l.client, req, &postings)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := company.Company
+	if name == "" {
+		name = company.Token
+	}
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+
+	for _, posting := range postings {
+		openLinks = append(openLinks, posting.HostedURL)
+
+		// The title is the most reliable signal, but some companies only mark internships through the commitment.
+		jobType, ok := ClassifyJobType(posting.Text)
+		if !ok {
+			jobType, ok = ClassifyJobType(posting.Categories.Commitment)
+		}
+		if !ok {
+			continue
+		}
+
+		firstSeen := time.Now()
+		if posting.CreatedAt > 0 {
+			firstSeen = time.UnixMilli(posting.CreatedAt)
+		}
+
+		localJobs = append(localJobs, models.Job{
+			Company:         name,
+			Location:        posting.Categories.Location,
+			Role:            posting.Text,
+			JobType:         jobType,
+			ApplicationLink: posting.HostedURL,
+			FirstSeen:       firstSeen,
+			Source:          "Lever",
+			SourceURL:       postingsURL,
+		})
+	}
+
+	return localJobs, openLinks, nil
+}
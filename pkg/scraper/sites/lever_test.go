@@ -0,0 +1,77 @@
+package sites
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+func newFixtureLever(t *testing.T) *lever {
+	t.Helper()
+
+	server := newFixtureServer(t, "GET /v0/postings/globex", "lever_postings.json")
+
+	l := NewLever(newTestLogger(), newTestDB(t), nil, nil)
+	l.baseURL = server.URL
+
+	return l
+}
+
+func TestLeverScrapeCompany(t *testing.T) {
+	l := newFixtureLever(t)
+
+	company := models.Board{Token: "globex", Company: "Globex"}
+	postingsURL := l.postingsURL(company)
+	jobs, links, err := l.scrapeCompany(company, postingsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(links) != 3 {
+		t.Errorf("expected every posting's link, got %d", len(links))
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected the full-time role to be left out, got %d jobs", len(jobs))
+	}
+
+	intern := jobs[0]
+	if intern.JobType != models.INTERN || intern.Company != "Globex" || intern.Location != "San Francisco, CA" {
+		t.Errorf("unexpected job %+v", intern)
+	}
+	if intern.Source != "Lever" || intern.SourceURL != postingsURL || intern.ApplicationLink != "https://jobs.lever.co/globex/8a1f0c2e-0001" {
+		t.Errorf("unexpected source or link for job %+v", intern)
+	}
+	if want := time.Date(2026, 9, 11, 14, 0, 0, 0, time.UTC); !intern.FirstSeen.Equal(want) {
+		t.Errorf("expected FirstSeen from createdAt, got %s", intern.FirstSeen)
+	}
+
+	// The title alone doesn't say this is an internship, so the commitment does.
+	if jobs[1].JobType != models.INTERN || jobs[1].Role != "Product Designer" {
+		t.Errorf("expected an internship from the commitment, got %+v", jobs[1])
+	}
+}
+
+func TestLeverCompanyFallsBackToToken(t *testing.T) {
+	l := newFixtureLever(t)
+
+	company := models.Board{Token: "globex"}
+	jobs, _, err := l.scrapeCompany(company, l.postingsURL(company))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 2 || jobs[0].Company != "globex" {
+		t.Errorf("expected the company token as the company, got %+v", jobs)
+	}
+}
+
+func TestLeverScrapeCompanyError(t *testing.T) {
+	l := newFixtureLever(t)
+
+	company := models.Board{Token: "missing"}
+	_, _, err := l.scrapeCompany(company, l.postingsURL(company))
+	if err == nil {
+		t.Error("expected an error for a company that doesn't exist")
+	}
+}
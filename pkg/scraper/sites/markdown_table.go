@@ -0,0 +1,323 @@
+package sites
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// continuationMarker is used by community READMEs in place of the company name for additional roles at the previous company.
+const continuationMarker = "↳"
+
+var (
+	htmlTableRegex   = regexp.MustCompile(`(?is)<table[^>]*>(.*?)</table>`)
+	htmlRowRegex     = regexp.MustCompile(`(?is)<tr[^>]*>(.*?)</tr>`)
+	htmlCellRegex    = regexp.MustCompile(`(?is)<t([hd])[^>]*>(.*?)</t[hd]>`)
+	hrefRegex        = regexp.MustCompile(`(?i)href="([^"]+)"`)
+	markdownURLRegex = regexp.MustCompile(`\[[^\]]*\]\(([^)\s]+)\)`)
+	markdownTxtRegex = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	summaryRegex     = regexp.MustCompile(`(?is)<summary[^>]*>.*?</summary>`)
+	lineBreakRegex   = regexp.MustCompile(`(?i)</?br\s*/?>`)
+	tagRegex         = regexp.MustCompile(`<[^>]*>`)
+	separatorRegex   = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+)
+
+type tableColumns struct {
+	company, role, location, link, date int
+}
+
+// tableRow is a single job parsed out of a README table, with the cells already cleaned.
+type tableRow struct {
+	Company  string
+	Role     string
+	Location string
+	Link     string
+	Date     string
+}
+
+type markdownTable struct {
+	log     *zap.SugaredLogger
+	db      *gorm.DB
+	jobChan *chan models.Job
+	site    models.Site
+	client  *http.Client
+}
+
+func NewMarkdownTable(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, site models.Site) *markdownTable {
+	return &markdownTable{
+		log:     log,
+		db:      db,
+		jobChan: jobChan,
+		site:    site,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (mt *markdownTable) Scrape() ([]models.Job, error) {
+	mt.log.Infof("Starting Scrape: %s", mt.site.URL)
+
+	req, err := http.NewRequest(http.MethodGet, mt.site.URL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := mt.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// An error page would otherwise be parsed as a README without tables, closing every job from the site.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 status code from %s: %d", mt.site.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := parseJobTables(string(body))
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+
+	for _, row := range rows {
+		openLinks = append(openLinks, row.Link)
+		localJobs = append(localJobs, models.Job{
+			Company:         row.Company,
+			Location:        row.Location,
+			Role:            row.Role,
+			JobType:         mt.site.JobType,
+			ApplicationLink: row.Link,
+			FirstSeen:       parseDatePosted(row.Date, time.Now()),
+			Source:          mt.site.Name,
+			SourceURL:       mt.site.URL,
+		})
+	}
+
+	jobs := saveJobs(mt.db, mt.log, mt.jobChan, localJobs)
+
+	// Closed listings lose their application link, so they are closed as missing from the table.
+	scraper.SyncJobStatuses(mt.db, mt.log, mt.site.URL, true, openLinks, nil)
+
+	mt.log.Infof("Finished Scrape: %s | %d jobs", mt.site.URL, len(localJobs))
+
+	return jobs, nil
+}
+
+// parseJobTables extracts jobs from every markdown or HTML table in a README that has recognizable column headers.
+// Rows without an application link, such as closed listings, are skipped.
+func parseJobTables(body string) []tableRow {
+	rows := []tableRow{}
+
+	for _, table := range htmlTableRegex.FindAllStringSubmatch(body, -1) {
+		rows = append(rows, parseRows(htmlTableRows(table[1]))...)
+	}
+
+	// HTML tables are removed so that pipes within them are not mistaken for a markdown table.
+	body = htmlTableRegex.ReplaceAllString(body, "")
+
+	for _, table := range markdownTables(body) {
+		rows = append(rows, parseRows(table)...)
+	}
+
+	return rows
+}
+
+func htmlTableRows(table string) [][]string {
+	rows := [][]string{}
+
+	for _, row := range htmlRowRegex.FindAllStringSubmatch(table, -1) {
+		cells := []string{}
+		for _, cell := range htmlCellRegex.FindAllStringSubmatch(row[1], -1) {
+			cells = append(cells, strings.TrimSpace(cell[2]))
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	}
+
+	return rows
+}
+
+// markdownTables groups consecutive pipe-delimited lines into tables, dropping the header separator line.
+func markdownTables(body string) [][][]string {
+	tables := [][][]string{}
+	current := [][]string{}
+
+	flush := func() {
+		if len(current) > 1 {
+			tables = append(tables, current)
+		}
+		current = [][]string{}
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+
+		if !strings.HasPrefix(line, "|") {
+			flush()
+			continue
+		}
+
+		if separatorRegex.MatchString(line) {
+			continue
+		}
+
+		line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+
+		cells := []string{}
+		for _, cell := range strings.Split(line, "|") {
+			cells = append(cells, strings.TrimSpace(cell))
+		}
+		current = append(current, cells)
+	}
+	flush()
+
+	return tables
+}
+
+// parseRows treats the first row as the header and maps the remaining rows into jobs.
+func parseRows(table [][]string) []tableRow {
+	if len(table) < 2 {
+		return nil
+	}
+
+	columns, ok := findColumns(table[0])
+	if !ok {
+		return nil
+	}
+
+	rows := []tableRow{}
+	company := ""
+
+	for _, cells := range table[1:] {
+		cell := func(i int) string {
+			if i < 0 || i >= len(cells) {
+				return ""
+			}
+			return cells[i]
+		}
+
+		rowCompany := cleanCell(cell(columns.company))
+		if rowCompany == continuationMarker || rowCompany == "" {
+			rowCompany = company
+		} else {
+			company = rowCompany
+		}
+
+		link := cellLink(cell(columns.link))
+
+		if rowCompany == "" || link == "" {
+			continue
+		}
+
+		rows = append(rows, tableRow{
+			Company:  rowCompany,
+			Role:     cleanCell(cell(columns.role)),
+			Location: cleanLocations(cell(columns.location)),
+			Link:     link,
+			Date:     cleanCell(cell(columns.date)),
+		})
+	}
+
+	return rows
+}
+
+func findColumns(header []string) (tableColumns, bool) {
+	columns := tableColumns{company: -1, role: -1, location: -1, link: -1, date: -1}
+
+	for i, name := range header {
+		name = strings.ToLower(cleanCell(name))
+
+		switch {
+		case strings.Contains(name, "company"):
+			columns.company = i
+		case strings.Contains(name, "role"), strings.Contains(name, "position"), strings.Contains(name, "title"):
+			columns.role = i
+		case strings.Contains(name, "location"):
+			columns.location = i
+		case strings.Contains(name, "appl"), strings.Contains(name, "link"):
+			columns.link = i
+		case strings.Contains(name, "date"), strings.Contains(name, "posted"), strings.Contains(name, "age"):
+			columns.date = i
+		}
+	}
+
+	return columns, columns.company >= 0 && columns.role >= 0 && columns.link >= 0
+}
+
+// cleanCell strips HTML tags, markdown emphasis and links from a cell, leaving only its text.
+func cleanCell(cell string) string {
+	cell = markdownTxtRegex.ReplaceAllString(cell, "$1")
+	cell = tagRegex.ReplaceAllString(cell, " ")
+	cell = strings.ReplaceAll(cell, "**", "")
+	cell = html.UnescapeString(cell)
+	return strings.Join(strings.Fields(cell), " ")
+}
+
+// cleanLocations flattens a location cell, including collapsed <details> cells listing several locations.
+func cleanLocations(cell string) string {
+	cell = summaryRegex.ReplaceAllString(cell, "")
+
+	locations := []string{}
+	for _, location := range lineBreakRegex.Split(cell, -1) {
+		location = cleanCell(location)
+		if location != "" {
+			locations = append(locations, location)
+		}
+	}
+
+	return strings.Join(locations, ", ")
+}
+
+func cellLink(cell string) string {
+	if match := hrefRegex.FindStringSubmatch(cell); len(match) == 2 {
+		return html.UnescapeString(match[1])
+	}
+	if match := markdownURLRegex.FindStringSubmatch(cell); len(match) == 2 {
+		return match[1]
+	}
+	return ""
+}
+
+// parseDatePosted understands both relative ages, such as "3d" or "1mo", and dates without a year, such as "Jul 13".
+func parseDatePosted(date string, now time.Time) time.Time {
+	if date == "" {
+		return now
+	}
+
+	for _, layout := range []string{"Jan 02", "Jan 2"} {
+		t, err := time.Parse(layout, date)
+		if err != nil {
+			continue
+		}
+
+		t = time.Date(now.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		if t.After(now) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t
+	}
+
+	duration, err := scraper.ParseDuration(date)
+	if err != nil {
+		return now
+	}
+
+	return now.Add(-duration)
+}
@@ -0,0 +1,76 @@
+package sites
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+func TestParseJobTables(t *testing.T) {
+	body, err := os.ReadFile("testdata/internships_readme.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []tableRow{
+		{
+			Company:  "Jane Street",
+			Role:     "Software Engineer Intern",
+			Location: "New York, NY",
+			Link:     "https://www.janestreet.com/join-jane-street/position/1/",
+			Date:     "2d",
+		},
+		{
+			Company:  "Jane Street",
+			Role:     "Quantitative Trader Intern",
+			Location: "New York, NY, London, UK, Hong Kong",
+			Link:     "https://www.janestreet.com/join-jane-street/position/2/",
+			Date:     "5d",
+		},
+		{
+			Company:  "Stripe",
+			Role:     "Software Engineering Intern & Co-op",
+			Location: "Remote in Canada",
+			Link:     "https://stripe.com/jobs/listing/1?utm_source=Simplify&ref=Simplify",
+			Date:     "0d",
+		},
+		{
+			Company:  "Ramp",
+			Role:     "Software Engineer, New Grad",
+			Location: "New York, NY",
+			Link:     "https://jobs.ashbyhq.com/ramp/1",
+			Date:     "Sep 03",
+		},
+		{
+			Company:  "Ramp",
+			Role:     "Data Scientist, New Grad",
+			Location: "San Francisco, CA, New York, NY",
+			Link:     "https://jobs.ashbyhq.com/ramp/2",
+			Date:     "Sep 04",
+		},
+	}
+
+	rows := parseJobTables(string(body))
+
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, with the closed ones skipped, got %d: %+v", len(want), len(rows), rows)
+	}
+
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d:\n got %+v\nwant %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestMarkdownTableScrapeError(t *testing.T) {
+	server := newFixtureServer(t, "GET /README.md", "internships_readme.md")
+
+	mt := NewMarkdownTable(newTestLogger(), newTestDB(t), nil, models.Site{Name: "README", URL: server.URL + "/missing.md"})
+
+	_, err := mt.Scrape()
+	if err == nil {
+		t.Error("expected an error for a non-200 response")
+	}
+}
@@ -0,0 +1,245 @@
+package sites
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// feedDocument decodes both RSS 2.0 (<rss><channel><item>) and Atom (<feed><entry>) documents.
+type feedDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	PubDate string `xml:"pubDate"`
+	Author  string `xml:"author"`
+	Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+type atomEntry struct {
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Author    struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+}
+
+// feedItem is the common shape of an RSS item or Atom entry.
+type feedItem struct {
+	Title     string
+	Link      string
+	Author    string
+	Published time.Time
+}
+
+var rssDateLayouts = []string{time.RFC1123Z, time.RFC1123, time.RFC822Z, time.RFC822, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", time.RFC3339}
+
+type rss struct {
+	log          *zap.SugaredLogger
+	db           *gorm.DB
+	jobChan      *chan models.Job
+	feed         models.RSSFeed
+	titlePattern *regexp.Regexp
+	client       *http.Client
+}
+
+func NewRSS(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, feed models.RSSFeed) *rss {
+	r := &rss{
+		log:     log,
+		db:      db,
+		jobChan: jobChan,
+		feed:    feed,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if feed.TitlePattern != "" {
+		// The pattern is validated when the config is loaded.
+		r.titlePattern = regexp.MustCompile(feed.TitlePattern)
+	}
+
+	return r
+}
+
+func (r *rss) Scrape() ([]models.Job, error) {
+	r.log.Infof("Starting Scrape: %s", r.feed.URL)
+
+	req, err := http.NewRequest(http.MethodGet, r.feed.URL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := r.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 status code from %s: %d", r.feed.URL, resp.StatusCode)
+	}
+
+	document := feedDocument{}
+
+	err = xml.NewDecoder(resp.Body).Decode(&document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+
+	for _, item := range document.items() {
+		if item.Link != "" {
+			openLinks = append(openLinks, item.Link)
+		}
+
+		job, ok := r.toJob(item)
+		if ok {
+			localJobs = append(localJobs, job)
+		}
+	}
+
+	jobs := saveJobs(r.db, r.log, r.jobChan, localJobs)
+
+	// A feed only lists its latest items, so those that drop out of it may still be open. Items are only reopened.
+	scraper.SyncJobStatuses(r.db, r.log, r.feed.URL, false, openLinks, nil)
+
+	r.log.Infof("Finished Scrape: %s | %d jobs", r.feed.URL, len(localJobs))
+
+	return jobs, nil
+}
+
+// toJob applies the feed's extraction rules to an item. Items that can not be classified or have no link are skipped.
+func (r *rss) toJob(item feedItem) (models.Job, bool) {
+	if item.Link == "" {
+		return models.Job{}, false
+	}
+
+	company := r.feed.Company
+	if company == "" {
+		company = item.Author
+	}
+	role := item.Title
+	location := r.feed.Location
+
+	if r.titlePattern != nil {
+		match := r.titlePattern.FindStringSubmatch(item.Title)
+		if match == nil {
+			return models.Job{}, false
+		}
+
+		for i, name := range r.titlePattern.SubexpNames() {
+			value := strings.TrimSpace(match[i])
+			if value == "" {
+				continue
+			}
+			switch name {
+			case "company":
+				company = value
+			case "role":
+				role = value
+			case "location":
+				location = value
+			}
+		}
+	}
+
+	if company == "" {
+		company = r.feed.Name
+	}
+
+	jobType := r.feed.JobType
+	if jobType == "" {
+		var ok bool
+		jobType, ok = ClassifyJobType(item.Title)
+		if !ok {
+			return models.Job{}, false
+		}
+	}
+
+	return models.Job{
+		Company:         company,
+		Location:        location,
+		Role:            role,
+		JobType:         jobType,
+		ApplicationLink: item.Link,
+		FirstSeen:       item.Published,
+		Source:          r.feed.Name,
+		SourceURL:       r.feed.URL,
+	}, true
+}
+
+func (d *feedDocument) items() []feedItem {
+	items := []feedItem{}
+
+	for _, item := range d.Channel.Items {
+		author := item.Author
+		if author == "" {
+			author = item.Creator
+		}
+		items = append(items, feedItem{
+			Title:     strings.TrimSpace(item.Title),
+			Link:      strings.TrimSpace(item.Link),
+			Author:    strings.TrimSpace(author),
+			Published: parseFeedDate(item.PubDate),
+		})
+	}
+
+	for _, entry := range d.Entries {
+		link := ""
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+		items = append(items, feedItem{
+			Title:     strings.TrimSpace(entry.Title),
+			Link:      strings.TrimSpace(link),
+			Author:    strings.TrimSpace(entry.Author.Name),
+			Published: parseFeedDate(published),
+		})
+	}
+
+	return items
+}
+
+func parseFeedDate(date string) time.Time {
+	date = strings.TrimSpace(date)
+	for _, layout := range rssDateLayouts {
+		t, err := time.Parse(layout, date)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
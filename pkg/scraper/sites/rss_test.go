@@ -0,0 +1,235 @@
+package sites
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+func newFixtureRSS(t *testing.T, fixture string, feed models.RSSFeed) *rss {
+	t.Helper()
+
+	server := newFixtureServer(t, "GET /feed", fixture)
+	feed.URL = server.URL + "/feed"
+
+	return NewRSS(newTestLogger(), newTestDB(t), nil, feed)
+}
+
+func TestRSSKeepsDroppedItemsOpen(t *testing.T) {
+	r := newFixtureRSS(t, "rss_feed.xml", models.RSSFeed{Name: "Early Career Jobs"})
+
+	// Every item in the feed is already saved, so scraping doesn't source logos or notify anyone.
+	links := []string{
+		"https://jobs.example.com/acme/software-engineer-intern",
+		"https://jobs.example.com/globex/new-grad-data-scientist",
+		"https://jobs.example.com/acme/dropped-intern",
+	}
+	for _, link := range links {
+		err := r.db.Create(&models.Job{
+			Company:         "Acme",
+			Role:            "Software Engineer Intern",
+			JobType:         models.INTERN,
+			ApplicationLink: link,
+			FirstSeen:       time.Now(),
+			Source:          r.feed.Name,
+			SourceURL:       r.feed.URL,
+		}).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var listed models.Job
+	err := r.db.Where("application_link = ?", links[0]).First(&listed).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = listed.SetStatus(r.db, models.JobStatusClosed, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Scrape()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dropped models.Job
+	err = r.db.Where("application_link = ?", links[2]).First(&dropped).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dropped.Status != models.JobStatusOpen {
+		t.Errorf("expected the item that dropped out of the feed to stay open, got %q", dropped.Status)
+	}
+
+	var relisted models.Job
+	err = r.db.Where("application_link = ?", links[0]).First(&relisted).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if relisted.Status != models.JobStatusReopened {
+		t.Errorf("expected the closed item still in the feed to be reopened, got %q", relisted.Status)
+	}
+}
+
+func TestRSSLeavesOtherSourcesJobs(t *testing.T) {
+	r := newFixtureRSS(t, "rss_feed.xml", models.RSSFeed{Name: "Early Career Jobs"})
+
+	// The board the job was saved from closed it, so the feed listing it too mustn't reopen it.
+	job := models.Job{
+		Company:         "Acme",
+		Role:            "Software Engineer Intern",
+		JobType:         models.INTERN,
+		ApplicationLink: "https://jobs.example.com/acme/software-engineer-intern",
+		FirstSeen:       time.Now(),
+		Source:          "Greenhouse",
+		SourceURL:       "https://boards-api.greenhouse.io/v1/boards/acme/jobs",
+	}
+	err := r.db.Create(&job).Error
+	if err == nil {
+		err = job.SetStatus(r.db, models.JobStatusClosed, "removed from source")
+	}
+	if err == nil {
+		// The feed's other item is already saved, so scraping doesn't source its logo.
+		err = r.db.Create(&models.Job{
+			Company:         "Globex",
+			Role:            "New Grad Data Scientist",
+			JobType:         models.NEW_GRAD,
+			ApplicationLink: "https://jobs.example.com/globex/new-grad-data-scientist",
+			FirstSeen:       time.Now(),
+			Source:          r.feed.Name,
+			SourceURL:       r.feed.URL,
+		}).Error
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Scrape()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var closed models.Job
+	err = r.db.Where("id = ?", job.ID).First(&closed).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != models.JobStatusClosed {
+		t.Errorf("expected the job closed by its own source to stay closed, got %q", closed.Status)
+	}
+}
+
+func TestRSSItems(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []feedItem
+	}{
+		{
+			fixture: "rss_feed.xml",
+			want: []feedItem{
+				{
+					Title:     "Acme - Software Engineer Intern - New York, NY",
+					Link:      "https://jobs.example.com/acme/software-engineer-intern",
+					Author:    "Acme",
+					Published: time.Date(2026, 9, 15, 14, 30, 0, 0, time.UTC),
+				},
+				{
+					Title:     "Globex - New Grad Data Scientist - Remote",
+					Link:      "https://jobs.example.com/globex/new-grad-data-scientist",
+					Author:    "Globex",
+					Published: time.Date(2026, 9, 14, 9, 0, 0, 0, time.UTC),
+				},
+				{
+					Title:     "Initech - Senior Staff Engineer - Austin, TX",
+					Link:      "https://jobs.example.com/initech/senior-staff-engineer",
+					Published: time.Date(2026, 9, 14, 8, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			fixture: "atom_feed.xml",
+			want: []feedItem{
+				{
+					Title:     "Hardware Engineering Co-op",
+					Link:      "https://careers.initech.example/jobs/301",
+					Author:    "Initech",
+					Published: time.Date(2026, 9, 16, 14, 15, 0, 0, time.UTC),
+				},
+				{
+					Title:     "Entry Level Software Engineer",
+					Link:      "https://careers.initech.example/jobs/302",
+					Published: time.Date(2026, 9, 12, 8, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			body, err := os.ReadFile("testdata/" + test.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			document := feedDocument{}
+			err = xml.Unmarshal(body, &document)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items := document.items()
+			if len(items) != len(test.want) {
+				t.Fatalf("expected %d items, got %d: %+v", len(test.want), len(items), items)
+			}
+			for i, want := range test.want {
+				got := items[i]
+				if got.Title != want.Title || got.Link != want.Link || got.Author != want.Author || !got.Published.Equal(want.Published) {
+					t.Errorf("item %d:\n got %+v\nwant %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRSSToJob(t *testing.T) {
+	feed := models.RSSFeed{
+		Name:         "Early Career Jobs",
+		URL:          "https://jobs.example.com/feed",
+		TitlePattern: `^(?P<company>[^-]+) - (?P<role>.+) - (?P<location>[^-]+)$`,
+	}
+	r := NewRSS(newTestLogger(), nil, nil, feed)
+
+	job, ok := r.toJob(feedItem{Title: "Globex - New Grad Data Scientist - Remote", Link: "https://jobs.example.com/globex/1", Author: "Jobs Bot"})
+	if !ok {
+		t.Fatal("expected the item to become a job")
+	}
+	if job.Company != "Globex" || job.Role != "New Grad Data Scientist" || job.Location != "Remote" || job.JobType != models.NEW_GRAD {
+		t.Errorf("expected the title's groups and a new grad position, got %+v", job)
+	}
+	if job.Source != feed.Name || job.SourceURL != feed.URL {
+		t.Errorf("expected the feed as the source, got %q and %q", job.Source, job.SourceURL)
+	}
+
+	skipped := []feedItem{
+		{Title: "Initech - Senior Staff Engineer - Austin, TX", Link: "https://jobs.example.com/initech/1"},
+		{Title: "Intern posting without the pattern's dashes", Link: "https://jobs.example.com/acme/1"},
+		{Title: "Acme - Software Engineer Intern - New York, NY"},
+	}
+	for _, item := range skipped {
+		if job, ok := r.toJob(item); ok {
+			t.Errorf("expected %q to be skipped, got %+v", item.Title, job)
+		}
+	}
+
+	// Without a pattern, the whole title is the role and the item's author the company.
+	r = NewRSS(newTestLogger(), nil, nil, models.RSSFeed{Name: "Initech Careers", JobType: models.INTERN, Location: "Austin, TX"})
+	job, ok = r.toJob(feedItem{Title: "Hardware Engineering Co-op", Link: "https://careers.initech.example/jobs/301", Author: "Initech"})
+	if !ok || job.Company != "Initech" || job.Role != "Hardware Engineering Co-op" || job.Location != "Austin, TX" || job.JobType != models.INTERN {
+		t.Errorf("expected the item's author and the feed's location and type, got %+v", job)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -19,6 +20,11 @@ type simplifyJob struct {
 	ApplicationLink string   `json:"url"`
 	DatePosted      int      `json:"date_posted"`
 	DateUpdated     int      `json:"date_updated"`
+	Active          bool     `json:"active"`
+	IsVisible       bool     `json:"is_visible"`
+	Sponsorship     string   `json:"sponsorship"`
+	Terms           []string `json:"terms"`
+	Category        string   `json:"category"`
 }
 
 type simplifyJobs struct {
@@ -74,8 +80,16 @@ func (sj *simplifyJobs) Scrape() ([]models.Job, error) {
 		}
 
 		localJobs := []models.Job{}
+		openLinks := []string{}
+		closedLinks := []string{}
 
 		for _, job := range simplifyJobs {
+			if !job.Active || !job.IsVisible {
+				closedLinks = append(closedLinks, job.ApplicationLink)
+				continue
+			}
+			openLinks = append(openLinks, job.ApplicationLink)
+
 			firstSeen := time.Unix(int64(job.DateUpdated), 0)
 			if firstSeen.Unix() < time.Now().Add(-35*24*time.Hour).Unix() {
 				continue
@@ -94,28 +108,15 @@ func (sj *simplifyJobs) Scrape() ([]models.Job, error) {
 				FirstSeen:       firstSeen,
 				Source:          source,
 				SourceURL:       url,
+				Sponsorship:     job.Sponsorship,
+				Terms:           job.Terms,
+				Category:        job.Category,
 			})
 		}
 
-		for _, job := range localJobs {
-			err := sj.db.Save(&job).Error
-
-			if err != nil {
-				if err == gorm.ErrDuplicatedKey {
-					continue
-				}
-				sj.log.Error(err)
-				continue
-			}
-
-			job.SourceLogo(sj.db)
-
-			if sj.jobChan != nil {
-				*sj.jobChan <- job
-			}
-		}
+		scraper.SyncJobStatuses(sj.db, sj.log, url, true, openLinks, closedLinks)
 
-		jobs = append(jobs, localJobs...)
+		jobs = append(jobs, saveJobs(sj.db, sj.log, sj.jobChan, localJobs)...)
 
 		sj.log.Infof("Finished Scrape: %s | %d jobs", url, len(localJobs))
 
@@ -0,0 +1,287 @@
+package sites
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"github.com/stephensulimani/internly-bot/pkg/scraper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// workdayPageSize is the largest page size the CXS API accepts.
+	workdayPageSize       = 20
+	workdayDefaultMaxJobs = 100
+	workdayDefaultSearch  = "intern"
+	workdayDelay          = time.Second
+)
+
+type workdaySearch struct {
+	AppliedFacets map[string]any `json:"appliedFacets"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	SearchText    string         `json:"searchText"`
+}
+
+type workdayPosting struct {
+	Title         string `json:"title"`
+	ExternalPath  string `json:"externalPath"`
+	LocationsText string `json:"locationsText"`
+	PostedOn      string `json:"postedOn"`
+}
+
+type workdaySearchResponse struct {
+	Total       int              `json:"total"`
+	JobPostings []workdayPosting `json:"jobPostings"`
+}
+
+type workdayPostingResponse struct {
+	JobPostingInfo struct {
+		Title               string   `json:"title"`
+		Location            string   `json:"location"`
+		AdditionalLocations []string `json:"additionalLocations"`
+		StartDate           string   `json:"startDate"`
+		PostedOn            string   `json:"postedOn"`
+		ExternalURL         string   `json:"externalUrl"`
+	} `json:"jobPostingInfo"`
+}
+
+type workday struct {
+	log     *zap.SugaredLogger
+	db      *gorm.DB
+	jobChan *chan models.Job
+	sites   []models.WorkdaySite
+	delay   time.Duration
+	client  *http.Client
+}
+
+func NewWorkday(log *zap.SugaredLogger, db *gorm.DB, jobChan *chan models.Job, sites []models.WorkdaySite) *workday {
+	return &workday{
+		log:     log,
+		db:      db,
+		jobChan: jobChan,
+		sites:   sites,
+		delay:   workdayDelay,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (w *workday) Scrape() ([]models.Job, error) {
+	jobs := []models.Job{}
+
+	for _, site := range w.sites {
+		searchURL := fmt.Sprintf("%s/wday/cxs/%s/%s/jobs", workdayBaseURL(site), url.PathEscape(site.Tenant), url.PathEscape(site.Site))
+		w.log.Infof("Starting Scrape: %s", searchURL)
+
+		localJobs, openLinks, complete, err := w.scrapeSite(site, searchURL)
+		if err != nil {
+			w.log.Errorf("Error scraping Workday site %s/%s: %v", site.Host, site.Site, err)
+		}
+
+		// Postings gathered before an error are still saved, since the pages already fetched are valid.
+		jobs = append(jobs, saveJobs(w.db, w.log, w.jobChan, localJobs)...)
+
+		// Missing postings can only be closed when every search result was seen.
+		scraper.SyncJobStatuses(w.db, w.log, searchURL, complete, openLinks, nil)
+
+		w.log.Infof("Finished Scrape: %s | %d jobs", searchURL, len(localJobs))
+	}
+
+	return jobs, nil
+}
+
+// scrapeSite pages through the search results, returning the new jobs, the links of every result
+// and whether every result was seen before reaching the configured maximum.
+func (w *workday) scrapeSite(site models.WorkdaySite, searchURL string) ([]models.Job, []string, bool, error) {
+	maxJobs := site.MaxJobs
+	if maxJobs <= 0 {
+		maxJobs = workdayDefaultMaxJobs
+	}
+
+	searchText := site.SearchText
+	if searchText == "" {
+		searchText = workdayDefaultSearch
+	}
+
+	company := site.Company
+	if company == "" {
+		company = site.Tenant
+	}
+
+	localJobs := []models.Job{}
+	openLinks := []string{}
+	// Workday only reports the total on the first page, returning 0 on the rest.
+	total := 0
+
+	for offset := 0; offset < maxJobs; offset += workdayPageSize {
+		if offset > 0 {
+			time.Sleep(w.delay)
+		}
+
+		limit := min(workdayPageSize, maxJobs-offset)
+
+		page, err := w.search(searchURL, workdaySearch{
+			AppliedFacets: map[string]any{},
+			Limit:         limit,
+			Offset:        offset,
+			SearchText:    searchText,
+		})
+		if err != nil {
+			return localJobs, openLinks, false, err
+		}
+
+		if offset == 0 {
+			total = page.Total
+		}
+
+		for _, posting := range page.JobPostings {
+			applicationLink := fmt.Sprintf("%s/%s%s", workdayBaseURL(site), site.Site, posting.ExternalPath)
+			openLinks = append(openLinks, applicationLink)
+
+			jobType, ok := ClassifyJobType(posting.Title)
+			if !ok {
+				continue
+			}
+
+			// Only follow postings that are not already known, to keep the number of requests down.
+			var count int64
+			err := w.db.Model(&models.Job{}).Where("application_link = ?", applicationLink).Count(&count).Error
+			if err != nil {
+				return localJobs, openLinks, false, err
+			}
+			if count > 0 {
+				continue
+			}
+
+			time.Sleep(w.delay)
+
+			job, err := w.posting(site, posting)
+			if err != nil {
+				w.log.Errorf("Error fetching Workday posting %s: %v", posting.ExternalPath, err)
+				continue
+			}
+
+			localJobs = append(localJobs, models.Job{
+				Company:         company,
+				Location:        job.Location,
+				Role:            posting.Title,
+				JobType:         jobType,
+				ApplicationLink: applicationLink,
+				FirstSeen:       job.FirstSeen,
+				Source:          "Workday",
+				SourceURL:       searchURL,
+			})
+		}
+
+		// A page is compared against the limit it was requested with, since the last page is shortened by the
+		// maximum, and stopping at the maximum doesn't mean every result was seen.
+		if len(page.JobPostings) < limit || (total > 0 && offset+len(page.JobPostings) >= total) {
+			return localJobs, openLinks, true, nil
+		}
+	}
+
+	return localJobs, openLinks, false, nil
+}
+
+func (w *workday) search(searchURL string, search workdaySearch) (*workdaySearchResponse, error) {
+	body, err := json.Marshal(search)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, searchURL, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	response := workdaySearchResponse{}
+
+	err = fetchJSON(w.client, req, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// posting follows a single posting for its full location list and posted date.
+func (w *workday) posting(site models.WorkdaySite, posting workdayPosting) (*models.Job, error) {
+	postingURL := fmt.Sprintf("%s/wday/cxs/%s/%s%s", workdayBaseURL(site), url.PathEscape(site.Tenant), url.PathEscape(site.Site), posting.ExternalPath)
+
+	req, err := http.NewRequest(http.MethodGet, postingURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := workdayPostingResponse{}
+
+	err = fetchJSON(w.client, req, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := response.JobPostingInfo
+
+	locations := []string{}
+	if info.Location != "" {
+		locations = append(locations, info.Location)
+	} else if posting.LocationsText != "" {
+		locations = append(locations, posting.LocationsText)
+	}
+	locations = append(locations, info.AdditionalLocations...)
+
+	firstSeen, err := time.Parse(time.DateOnly, info.StartDate)
+	if err != nil {
+		firstSeen = parseWorkdayPostedOn(info.PostedOn)
+	}
+
+	return &models.Job{
+		Location:  strings.Join(locations, ", "),
+		FirstSeen: firstSeen,
+	}, nil
+}
+
+// workdayBaseURL builds the base URL for a site. A scheme may be included in the host, which is useful for local testing.
+func workdayBaseURL(site models.WorkdaySite) string {
+	if strings.HasPrefix(site.Host, "http://") || strings.HasPrefix(site.Host, "https://") {
+		return strings.TrimSuffix(site.Host, "/")
+	}
+	return "https://" + strings.TrimSuffix(site.Host, "/")
+}
+
+var workdayDaysAgoRegex = regexp.MustCompile(`(\d+)\+? Days? Ago`)
+
+// parseWorkdayPostedOn converts Workday's relative dates, such as "Posted 3 Days Ago", into a time.
+func parseWorkdayPostedOn(postedOn string) time.Time {
+	now := time.Now()
+
+	if strings.Contains(postedOn, "Yesterday") {
+		return now.Add(-24 * time.Hour)
+	}
+
+	match := workdayDaysAgoRegex.FindStringSubmatch(postedOn)
+	if len(match) == 2 {
+		days, err := strconv.Atoi(match[1])
+		if err == nil {
+			return now.Add(-time.Duration(days) * 24 * time.Hour)
+		}
+	}
+
+	return now
+}
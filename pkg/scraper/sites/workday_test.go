@@ -0,0 +1,135 @@
+package sites
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stephensulimani/internly-bot/pkg/models"
+)
+
+// newFakeWorkday serves total intern postings from the CXS search API, reporting the total only on the first page
+// like Workday does.
+func newFakeWorkday(t *testing.T, total int) (*httptest.Server, *[]workdaySearch) {
+	t.Helper()
+
+	searches := []workdaySearch{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /wday/cxs/acme/careers/jobs", func(w http.ResponseWriter, r *http.Request) {
+		search := workdaySearch{}
+		err := json.NewDecoder(r.Body).Decode(&search)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		searches = append(searches, search)
+
+		response := workdaySearchResponse{}
+		if search.Offset == 0 {
+			response.Total = total
+		}
+		for n := search.Offset; n < min(search.Offset+search.Limit, total); n++ {
+			response.JobPostings = append(response.JobPostings, workdayPosting{
+				Title:        fmt.Sprintf("Software Engineer Intern %d", n),
+				ExternalPath: fmt.Sprintf("/job/New-York/Intern_%d", n),
+				PostedOn:     "Posted Today",
+			})
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+	mux.HandleFunc("GET /wday/cxs/acme/careers/job/", func(w http.ResponseWriter, r *http.Request) {
+		response := workdayPostingResponse{}
+		response.JobPostingInfo.Location = "New York, NY"
+		response.JobPostingInfo.AdditionalLocations = []string{"Austin, TX"}
+		response.JobPostingInfo.StartDate = "2026-10-01"
+		json.NewEncoder(w).Encode(response)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &searches
+}
+
+func TestWorkdayScrapeSiteStopsAtMaxJobs(t *testing.T) {
+	server, searches := newFakeWorkday(t, 100)
+
+	w := NewWorkday(newTestLogger(), newTestDB(t), nil, nil)
+	w.delay = 0
+
+	site := models.WorkdaySite{Host: server.URL, Tenant: "acme", Site: "careers", MaxJobs: 30}
+	jobs, links, complete, err := w.scrapeSite(site, server.URL+"/wday/cxs/acme/careers/jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if complete {
+		t.Error("expected the results to be incomplete when stopping at the maximum")
+	}
+	if len(links) != 30 || len(jobs) != 30 {
+		t.Errorf("expected 30 links and jobs, got %d and %d", len(links), len(jobs))
+	}
+	if len(*searches) != 2 || (*searches)[1].Limit != 10 {
+		t.Errorf("expected a full page and a page of 10, got %+v", *searches)
+	}
+}
+
+func TestWorkdayScrapeSiteCompletes(t *testing.T) {
+	for _, total := range []int{0, 20, 25, 40} {
+		server, _ := newFakeWorkday(t, total)
+
+		w := NewWorkday(newTestLogger(), newTestDB(t), nil, nil)
+		w.delay = 0
+
+		site := models.WorkdaySite{Host: server.URL, Tenant: "acme", Site: "careers", MaxJobs: 100}
+		_, links, complete, err := w.scrapeSite(site, server.URL+"/wday/cxs/acme/careers/jobs")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !complete {
+			t.Errorf("total %d: expected the results to be complete", total)
+		}
+		if len(links) != total {
+			t.Errorf("total %d: expected %d links, got %d", total, total, len(links))
+		}
+	}
+}
+
+func TestWorkdayScrapeSiteFollowsPostings(t *testing.T) {
+	server, _ := newFakeWorkday(t, 1)
+
+	w := NewWorkday(newTestLogger(), newTestDB(t), nil, nil)
+	w.delay = 0
+
+	site := models.WorkdaySite{Host: server.URL, Tenant: "acme", Site: "careers"}
+	jobs, _, _, err := w.scrapeSite(site, server.URL+"/wday/cxs/acme/careers/jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+
+	job := jobs[0]
+	if job.Company != "acme" {
+		t.Errorf("expected the tenant as the company, got %q", job.Company)
+	}
+	if job.JobType != models.INTERN {
+		t.Errorf("expected an internship, got %q", job.JobType)
+	}
+	if job.Location != "New York, NY, Austin, TX" {
+		t.Errorf("unexpected location %q", job.Location)
+	}
+	if got := job.FirstSeen.Format("2006-01-02"); got != "2026-10-01" {
+		t.Errorf("expected the start date as FirstSeen, got %s", got)
+	}
+	if !strings.HasPrefix(job.ApplicationLink, server.URL+"/careers/job/") {
+		t.Errorf("unexpected application link %q", job.ApplicationLink)
+	}
+}
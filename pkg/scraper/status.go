@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"github.com/stephensulimani/internly-bot/pkg/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SyncJobStatuses updates the lifecycle of the jobs saved from a source after a successful scrape of it.
+// Jobs in closedLinks are closed, closed jobs in openLinks are reopened, and if complete is set, meaning openLinks lists
+// every posting the source has, any open job missing from openLinks is considered removed and closed.
+// Only jobs saved from sourceURL are changed, so that sources listing the same link can't keep closing and reopening it.
+func SyncJobStatuses(db *gorm.DB, log *zap.SugaredLogger, sourceURL string, complete bool, openLinks []string, closedLinks []string) {
+	const batchSize = 500
+
+	transition := func(links []string, where string, status models.JobStatus, reason string) {
+		for start := 0; start < len(links); start += batchSize {
+			batch := links[start:min(start+batchSize, len(links))]
+
+			var jobs []models.Job
+			err := db.Where("source_url = ? AND application_link IN ?", sourceURL, batch).Where(where, models.JobStatusClosed).Find(&jobs).Error
+			if err != nil {
+				log.Error(err)
+				return
+			}
+
+			for _, job := range jobs {
+				err = job.SetStatus(db, status, reason)
+				if err != nil {
+					log.Error(err)
+				}
+			}
+		}
+	}
+
+	transition(closedLinks, "status != ?", models.JobStatusClosed, "marked inactive by source")
+	transition(openLinks, "status = ?", models.JobStatusReopened, "listed again by source")
+
+	// An empty listing is more likely a transient failure than every posting closing at once.
+	if !complete || len(openLinks) == 0 {
+		return
+	}
+
+	open := make(map[string]bool, len(openLinks))
+	for _, link := range openLinks {
+		open[link] = true
+	}
+
+	var jobs []models.Job
+	err := db.Where("source_url = ? AND status != ?", sourceURL, models.JobStatusClosed).Find(&jobs).Error
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, job := range jobs {
+		if open[job.ApplicationLink] {
+			continue
+		}
+		err = job.SetStatus(db, models.JobStatusClosed, "removed from source")
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}